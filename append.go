@@ -0,0 +1,189 @@
+package nullable
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+/*
+AppendJSON appends the JSON encoding of the Nullable to dst and returns the extended buffer.
+The output is identical to MarshalJSON, including its errors.
+Nullables of bool, string, the sized and unsized integer and float types and time.Time are encoded without reflection or allocation, provided dst has enough capacity.
+Every other type, including named types based on those, is encoded with json.Marshal, as are strings holding invalid UTF-8.
+
+	buf := make([]byte, 0, 1024)
+	for _, record := range records {
+		buf, err = record.Score.AppendJSON(buf[:0])
+		...
+	}
+*/
+func (n Nullable[T]) AppendJSON(dst []byte) ([]byte, error) {
+	if n.ptr == nil {
+		return append(dst, "null"...), nil
+	}
+	if out, ok, err := n.appendJSONFast(dst); ok {
+		return out, err
+	}
+
+	raw, err := json.Marshal(*n.ptr)
+	if err != nil {
+		return dst, err
+	}
+	return append(dst, raw...), nil
+}
+
+/*
+isJSONFast reports whether p points to a type that appendJSONFast handles, so that callers only allocate a buffer for those.
+*/
+func isJSONFast(p any) bool {
+	switch p.(type) {
+	case *bool, *string, *int, *int8, *int16, *int32, *int64, *uint, *uint8, *uint16, *uint32, *uint64, *float32, *float64, *time.Time:
+		return true
+	}
+	return false
+}
+
+/*
+appendJSONFast appends the JSON encoding of the value held by n if its type is one that AppendJSON encodes without reflection.
+ok is false, and dst is returned unchanged, if the value has to be encoded with json.Marshal instead.
+n must hold a value.
+*/
+func (n Nullable[T]) appendJSONFast(dst []byte) (out []byte, ok bool, err error) {
+	switch p := any(n.ptr).(type) {
+	case *bool:
+		return strconv.AppendBool(dst, *p), true, nil
+	case *string:
+		if utf8.ValidString(*p) {
+			return appendJSONString(dst, *p), true, nil
+		}
+	case *int:
+		return strconv.AppendInt(dst, int64(*p), 10), true, nil
+	case *int8:
+		return strconv.AppendInt(dst, int64(*p), 10), true, nil
+	case *int16:
+		return strconv.AppendInt(dst, int64(*p), 10), true, nil
+	case *int32:
+		return strconv.AppendInt(dst, int64(*p), 10), true, nil
+	case *int64:
+		return strconv.AppendInt(dst, *p, 10), true, nil
+	case *uint:
+		return strconv.AppendUint(dst, uint64(*p), 10), true, nil
+	case *uint8:
+		return strconv.AppendUint(dst, uint64(*p), 10), true, nil
+	case *uint16:
+		return strconv.AppendUint(dst, uint64(*p), 10), true, nil
+	case *uint32:
+		return strconv.AppendUint(dst, uint64(*p), 10), true, nil
+	case *uint64:
+		return strconv.AppendUint(dst, *p, 10), true, nil
+	case *float32:
+		out, err := appendJSONFloat(dst, float64(*p), 32)
+		return out, true, err
+	case *float64:
+		out, err := appendJSONFloat(dst, *p, 64)
+		return out, true, err
+	case *time.Time:
+		if isJSONTime(*p) {
+			dst = append(dst, '"')
+			dst = p.AppendFormat(dst, time.RFC3339Nano)
+			return append(dst, '"'), true, nil
+		}
+	}
+	return dst, false, nil
+}
+
+/*
+appendJSONFloat appends f using the same format as encoding/json.
+*/
+func appendJSONFloat(dst []byte, f float64, bits int) ([]byte, error) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return dst, &json.UnsupportedValueError{
+			Value: reflect.ValueOf(f),
+			Str:   strconv.FormatFloat(f, 'g', -1, bits),
+		}
+	}
+
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	dst = strconv.AppendFloat(dst, f, format, -1, bits)
+	if format == 'e' {
+		// Clean up e-09 to e-9, like encoding/json.
+		n := len(dst)
+		if n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+	return dst, nil
+}
+
+const hexDigits = "0123456789abcdef"
+
+/*
+appendJSONString appends s, which must be valid UTF-8, as a quoted JSON string escaped like encoding/json.
+HTML characters and the line and paragraph separators are escaped.
+*/
+func appendJSONString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= ' ' && b != '"' && b != '\\' && b != '<' && b != '>' && b != '&' {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch b {
+			case '\\', '"':
+				dst = append(dst, '\\', b)
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hexDigits[b>>4], hexDigits[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		c, size := utf8.DecodeRuneInString(s[i:])
+		if c == '\u2028' || c == '\u2029' {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hexDigits[c&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
+
+/*
+isJSONTime reports whether t can be encoded as RFC 3339 by time.Time.MarshalJSON.
+Times that can't are left to MarshalJSON so that it reports the error.
+*/
+func isJSONTime(t time.Time) bool {
+	if y := t.Year(); y < 0 || y >= 10000 {
+		return false
+	}
+	_, offset := t.Zone()
+	return offset%60 == 0 && offset > -24*60*60 && offset < 24*60*60
+}
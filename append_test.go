@@ -0,0 +1,142 @@
+package nullable
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+/*
+checkAppendJSON compares n.AppendJSON with json.Marshal of the value held by n.
+*/
+func checkAppendJSON[T any](t *testing.T, n Nullable[T]) {
+	t.Helper()
+	got, gotErr := n.AppendJSON([]byte("prefix:"))
+	var want []byte
+	var wantErr error
+	if n.ptr == nil {
+		want, wantErr = json.Marshal(nil)
+	} else {
+		want, wantErr = json.Marshal(*n.ptr)
+	}
+	if (gotErr != nil) != (wantErr != nil) {
+		t.Errorf("n.AppendJSON(dst) err = %v. Expected %v.", gotErr, wantErr)
+	} else if gotErr == nil && string(got) != "prefix:"+string(want) {
+		t.Errorf("n.AppendJSON(dst) = %s. Expected prefix:%s.", got, want)
+	}
+}
+
+func TestAppendJSON(t *testing.T) {
+	checkAppendJSON(t, Null[int]())
+	checkAppendJSON(t, From(true))
+	checkAppendJSON(t, From(-10))
+	checkAppendJSON(t, From(int8(math.MinInt8)))
+	checkAppendJSON(t, From(int16(math.MaxInt16)))
+	checkAppendJSON(t, From(int32(math.MinInt32)))
+	checkAppendJSON(t, From(int64(math.MaxInt64)))
+	checkAppendJSON(t, From(uint(10)))
+	checkAppendJSON(t, From(uint8(math.MaxUint8)))
+	checkAppendJSON(t, From(uint16(math.MaxUint16)))
+	checkAppendJSON(t, From(uint32(math.MaxUint32)))
+	checkAppendJSON(t, From(uint64(math.MaxUint64)))
+	checkAppendJSON(t, From(1.5))
+	checkAppendJSON(t, From(1e21))
+	checkAppendJSON(t, From(1e-7))
+	checkAppendJSON(t, From(float32(3.4e38)))
+	checkAppendJSON(t, From(float32(1e-7)))
+	checkAppendJSON(t, From(math.Inf(1)))
+	checkAppendJSON(t, From(math.NaN()))
+	checkAppendJSON(t, From("hello"))
+	checkAppendJSON(t, From("<a href=\"x\">&</a>\\\n\r\t\b\f\x00\x1f"))
+	checkAppendJSON(t, From("   é \xff"))
+	checkAppendJSON(t, From(time.Date(2022, 6, 1, 12, 0, 0, 123, time.FixedZone("", 90*60))))
+	checkAppendJSON(t, From(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)))
+	checkAppendJSON(t, From(time.Date(2022, 6, 1, 12, 0, 0, 0, time.FixedZone("", 30))))
+	checkAppendJSON(t, From([]int{1, 2}))
+	checkAppendJSON(t, From(map[string]string{"<": ">"}))
+	checkAppendJSON(t, From(time.Second))
+}
+
+func TestAppendJSONAllocs(t *testing.T) {
+	buf := make([]byte, 0, 64)
+	i, f, s, b := From(10), From(1.5), From("hello"), From(true)
+	ts := From(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC))
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = i.AppendJSON(buf[:0])
+		buf, _ = f.AppendJSON(buf[:0])
+		buf, _ = s.AppendJSON(buf[:0])
+		buf, _ = b.AppendJSON(buf[:0])
+		buf, _ = ts.AppendJSON(buf[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("testing.AllocsPerRun(AppendJSON) = %v. Expected %v.", allocs, 0)
+	}
+}
+
+func TestMarshalJSONAllocs(t *testing.T) {
+	type S struct {
+		A int
+		B []string
+	}
+	v := S{A: 1, B: []string{"x"}}
+	n := From(v)
+	got := testing.AllocsPerRun(100, func() {
+		n.MarshalJSON()
+	})
+	expected := testing.AllocsPerRun(100, func() {
+		json.Marshal(v)
+	})
+	if got != expected {
+		t.Errorf("testing.AllocsPerRun(MarshalJSON) = %v. Expected %v.", got, expected)
+	}
+}
+
+func FuzzAppendJSON(f *testing.F) {
+	f.Add(int64(0), 0.0, "", false, int64(0))
+	f.Add(int64(-1), 1e-7, "< >", true, int64(1654084800123456789))
+	f.Add(int64(math.MaxInt64), 1e21, "\xff\x00", false, int64(math.MinInt64))
+	f.Fuzz(func(t *testing.T, i int64, fl float64, s string, b bool, unix int64) {
+		checkAppendJSON(t, From(i))
+		checkAppendJSON(t, From(int32(i)))
+		checkAppendJSON(t, From(uint64(i)))
+		checkAppendJSON(t, From(fl))
+		checkAppendJSON(t, From(float32(fl)))
+		checkAppendJSON(t, From(s))
+		checkAppendJSON(t, From(b))
+		checkAppendJSON(t, From(time.Unix(0, unix).In(time.FixedZone("", int(i%(48*60*60))))))
+	})
+}
+
+func BenchmarkAppendJSON(b *testing.B) {
+	buf := make([]byte, 0, 64)
+	n := From("hello, world")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf, _ = n.AppendJSON(buf[:0])
+	}
+}
+
+func BenchmarkMarshalJSONString(b *testing.B) {
+	n := From("hello, world")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		n.MarshalJSON()
+	}
+}
+
+func BenchmarkMarshalJSONFloat(b *testing.B) {
+	n := From(1234.5678)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		n.MarshalJSON()
+	}
+}
+
+func BenchmarkMarshalJSONReflect(b *testing.B) {
+	n := From(1234.5678)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		json.Marshal(*n.ptr)
+	}
+}
@@ -0,0 +1,131 @@
+package nullable
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/missingsemi/nullable/internal/textconv"
+)
+
+/*
+DefaultError is returned by ApplyDefaults when a default tag can't be parsed into the type of its field.
+*/
+type DefaultError struct {
+	Path string
+	Tag  string
+	Err  error
+}
+
+func (e *DefaultError) Error() string {
+	return fmt.Sprintf("invalid default %q for %s: %v", e.Tag, e.Path, e.Err)
+}
+
+func (e *DefaultError) Unwrap() error {
+	return e.Err
+}
+
+/*
+defaultable requires a method that fills in an absent Nullable from a default tag.
+*/
+type defaultable interface {
+	applyDefault(tag string) error
+}
+
+var defaultableType = reflect.TypeOf((*defaultable)(nil)).Elem()
+
+/*
+applyDefault implements defaultable for the Nullable type.
+Present Nullables, including explicit nulls, are left untouched.
+*/
+func (n *Nullable[T]) applyDefault(tag string) error {
+	if n.present {
+		return nil
+	}
+	var tmp T
+	if err := textconv.Parse(tag, reflect.ValueOf(&tmp).Elem()); err != nil {
+		return err
+	}
+	n.ptr = &tmp
+	n.present = true
+	n.defaulted = true
+	return nil
+}
+
+/*
+ApplyDefaults fills in absent Nullable fields of the struct pointed to by v from their default struct tags.
+Nested structs, pointers to structs, slices and arrays are walked recursively.
+Explicit nulls are preserved, and filled in fields report true from IsDefaulted.
+
+	type Config struct {
+		Port    nullable.Nullable[int]    `json:"port" default:"8080"`
+		Timeout nullable.Nullable[string] `json:"timeout" default:"30s"`
+	}
+
+	var config Config
+	json.Unmarshal([]byte(`{"timeout": null}`), &config)
+	nullable.ApplyDefaults(&config)
+	config.Port.Value()        // 8080
+	config.Timeout.IsNull()    // true
+
+Tags are parsed with the type's UnmarshalText method if it has one, with strconv for primitive types and as JSON otherwise.
+If a tag can't be parsed, or is set on a pointer to a Nullable, a *DefaultError holding the path of the field is returned.
+*/
+func ApplyDefaults(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("ApplyDefaults() called with a non-pointer or nil value")
+	}
+	return applyDefaults(rv.Elem(), "")
+}
+
+func applyDefaults(rv reflect.Value, path string) error {
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return nil
+		}
+		return applyDefaults(rv.Elem(), path)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := applyDefaults(rv.Index(i), path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		rt := rv.Type()
+		for i := 0; i < rt.NumField(); i++ {
+			field := rt.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fieldPath := field.Name
+			if path != "" {
+				fieldPath = path + "." + field.Name
+			}
+
+			fv := rv.Field(i)
+			if d, ok := fv.Addr().Interface().(defaultable); ok {
+				tag, ok := field.Tag.Lookup("default")
+				if !ok {
+					continue
+				}
+				if err := d.applyDefault(tag); err != nil {
+					return &DefaultError{Path: fieldPath, Tag: tag, Err: err}
+				}
+				continue
+			}
+			if field.Type.Kind() == reflect.Pointer && reflect.PtrTo(field.Type.Elem()).Implements(defaultableType) {
+				if tag, ok := field.Tag.Lookup("default"); ok {
+					return &DefaultError{Path: fieldPath, Tag: tag, Err: fmt.Errorf("unsupported field type %v", field.Type)}
+				}
+				continue
+			}
+			if err := applyDefaults(fv, fieldPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
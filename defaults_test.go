@@ -0,0 +1,165 @@
+package nullable
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	{
+		type S struct {
+			Int      Nullable[int]           `default:"10"`
+			Bool     Nullable[bool]          `default:"true"`
+			String   Nullable[string]        `default:"hello"`
+			Float    Nullable[float64]       `default:"1.5"`
+			Duration Nullable[time.Duration] `default:"1m"`
+			IP       Nullable[net.IP]        `default:"127.0.0.1"`
+			Slice    Nullable[[]int]         `default:"[1, 2]"`
+			NoTag    Nullable[int]
+		}
+		var s S
+		err := ApplyDefaults(&s)
+		if err != nil {
+			t.Errorf("ApplyDefaults(&s) = %v. Expected %v.", err, nil)
+		}
+		if s.Int.ptr == nil || *s.Int.ptr != 10 {
+			t.Errorf("s.Int = %+v. Expected %v.", s.Int, 10)
+		}
+		if s.Bool.ptr == nil || *s.Bool.ptr != true {
+			t.Errorf("s.Bool = %+v. Expected %v.", s.Bool, true)
+		}
+		if s.String.ptr == nil || *s.String.ptr != "hello" {
+			t.Errorf("s.String = %+v. Expected %v.", s.String, "hello")
+		}
+		if s.Float.ptr == nil || *s.Float.ptr != 1.5 {
+			t.Errorf("s.Float = %+v. Expected %v.", s.Float, 1.5)
+		}
+		if s.Duration.ptr == nil || *s.Duration.ptr != time.Minute {
+			t.Errorf("s.Duration = %+v. Expected %v.", s.Duration, time.Minute)
+		}
+		if s.IP.ptr == nil || !s.IP.ptr.Equal(net.IPv4(127, 0, 0, 1)) {
+			t.Errorf("s.IP = %+v. Expected %v.", s.IP, "127.0.0.1")
+		}
+		if s.Slice.ptr == nil || len(*s.Slice.ptr) != 2 {
+			t.Errorf("s.Slice = %+v. Expected %v.", s.Slice, []int{1, 2})
+		}
+		if s.Int.present == false || s.Int.defaulted == false {
+			t.Errorf("s.Int = %+v. Expected present and defaulted.", s.Int)
+		}
+		if s.NoTag.present == true {
+			t.Error("s.NoTag.IsPresent() = true. Expected false.")
+		}
+	}
+	{
+		type S struct {
+			Value Nullable[int] `json:"value" default:"10"`
+			Null  Nullable[int] `json:"null" default:"10"`
+		}
+		var s S
+		json.Unmarshal([]byte(`{"value": 5, "null": null}`), &s)
+		err := ApplyDefaults(&s)
+		if err != nil {
+			t.Errorf("ApplyDefaults(&s) = %v. Expected %v.", err, nil)
+		}
+		if s.Value.ptr == nil || *s.Value.ptr != 5 || s.Value.defaulted == true {
+			t.Errorf("s.Value = %+v. Expected %v.", s.Value, 5)
+		}
+		if s.Null.ptr != nil || s.Null.present == false || s.Null.defaulted == true {
+			t.Errorf("s.Null = %+v. Expected present null.", s.Null)
+		}
+	}
+	{
+		type Inner struct {
+			Port Nullable[int] `default:"8080"`
+		}
+		type S struct {
+			Inner
+			Ptr   *Inner
+			Nil   *Inner
+			Slice []Inner
+		}
+		s := S{Ptr: &Inner{}, Slice: make([]Inner, 2)}
+		err := ApplyDefaults(&s)
+		if err != nil {
+			t.Errorf("ApplyDefaults(&s) = %v. Expected %v.", err, nil)
+		}
+		if s.Inner.Port.ptr == nil || *s.Inner.Port.ptr != 8080 {
+			t.Errorf("s.Inner.Port = %+v. Expected %v.", s.Inner.Port, 8080)
+		}
+		if s.Ptr.Port.ptr == nil || *s.Ptr.Port.ptr != 8080 {
+			t.Errorf("s.Ptr.Port = %+v. Expected %v.", s.Ptr.Port, 8080)
+		}
+		if s.Nil != nil {
+			t.Errorf("s.Nil = %+v. Expected %v.", s.Nil, nil)
+		}
+		if s.Slice[1].Port.ptr == nil || *s.Slice[1].Port.ptr != 8080 {
+			t.Errorf("s.Slice[1].Port = %+v. Expected %v.", s.Slice[1].Port, 8080)
+		}
+	}
+	{
+		type Inner struct {
+			Port Nullable[int] `default:"eighty"`
+		}
+		type S struct {
+			Servers []Inner
+		}
+		s := S{Servers: make([]Inner, 1)}
+		err := ApplyDefaults(&s)
+		var defaultErr *DefaultError
+		if !errors.As(err, &defaultErr) {
+			t.Errorf("ApplyDefaults(&s) = %v. Expected *DefaultError.", err)
+		} else {
+			if defaultErr.Path != "Servers[0].Port" {
+				t.Errorf("defaultErr.Path = %v. Expected %v.", defaultErr.Path, "Servers[0].Port")
+			}
+			if !errors.Is(err, strconv.ErrSyntax) {
+				t.Errorf("errors.Is(err, strconv.ErrSyntax) = false. Expected true.")
+			}
+		}
+	}
+	{
+		var s struct {
+			Port *Nullable[int] `default:"8080"`
+		}
+		err := ApplyDefaults(&s)
+		var defaultErr *DefaultError
+		if !errors.As(err, &defaultErr) || defaultErr.Path != "Port" {
+			t.Errorf("ApplyDefaults(&s) = %v. Expected *DefaultError for Port.", err)
+		}
+		if s.Port != nil {
+			t.Errorf("s.Port = %+v. Expected nil.", s.Port)
+		}
+	}
+	{
+		var s struct{}
+		if err := ApplyDefaults(s); err == nil {
+			t.Errorf("ApplyDefaults(s) = %v. Expected error.", err)
+		}
+	}
+}
+
+func TestIsDefaulted(t *testing.T) {
+	{
+		tmp := 10
+		got := Nullable[int]{ptr: &tmp, present: true, defaulted: true}
+		if got.IsDefaulted() == false {
+			t.Error("got.IsDefaulted() = false. Expected true.")
+		}
+		got.Set(5)
+		if got.IsDefaulted() == true {
+			t.Error("got.IsDefaulted() = true after Set. Expected false.")
+		}
+	}
+	{
+		tmp := 10
+		got := Nullable[int]{ptr: &tmp, present: true, defaulted: true}
+		got.Clear()
+		if got.IsDefaulted() == true {
+			t.Error("got.IsDefaulted() = true after Clear. Expected false.")
+		}
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Package env populates structs of Nullable fields from environment variables.
+Unset variables leave fields absent, variables set to the null token (empty by default) make fields null, and any other value is parsed into the field.
+*/
+package env
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/missingsemi/nullable/internal/textconv"
+)
+
+/*
+nullableField is implemented by a pointer to any Nullable.
+*/
+type nullableField interface {
+	IsPresent() bool
+	IsNull() bool
+	Clear()
+}
+
+var nullableFieldType = reflect.TypeOf((*nullableField)(nil)).Elem()
+
+/*
+Error describes a variable that couldn't be decoded into its field.
+*/
+type Error struct {
+	Name  string
+	Field string
+	Err   error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (%s): %v", e.Name, e.Field, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+/*
+Errors holds every Error encountered by a single call to Decode.
+*/
+type Errors []*Error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return "invalid environment: " + strings.Join(msgs, "; ")
+}
+
+/*
+Decoder holds the options used to decode environment variables.
+The zero value is ready to use.
+*/
+type Decoder struct {
+	// Prefix is prepended to every variable name.
+	Prefix string
+	// Null is the value that marks a field as null. The default is the empty string.
+	Null string
+	// LookupEnv is used to look up variables. The default is os.LookupEnv.
+	LookupEnv func(key string) (string, bool)
+}
+
+/*
+Decode populates the struct pointed to by v using a zero Decoder.
+*/
+func Decode(v any) error {
+	return (&Decoder{}).Decode(v)
+}
+
+/*
+Decode populates the Nullable fields of the struct pointed to by v from the variables named by their env tags.
+
+	type Config struct {
+		Port     nullable.Nullable[int]    `env:"PORT"`
+		Database struct {
+			URL nullable.Nullable[string] `env:"URL"`
+		} `env:"DB_"`
+	}
+
+	var config Config
+	err := (&env.Decoder{Prefix: "APP_"}).Decode(&config) // reads APP_PORT and APP_DB_URL
+
+Nested structs and pointers to structs are decoded recursively, with their own env tag added to the prefix.
+Values are parsed with the UnmarshalText method of the type the Nullable holds if it has one, with strconv for primitive types and as JSON otherwise.
+A value that can't be parsed leaves its field null.
+Decoding continues past invalid values, and all of them are reported together as Errors.
+*/
+func (d *Decoder) Decode(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("Decode() called with a non-pointer to struct")
+	}
+
+	lookup := d.LookupEnv
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+
+	var errs Errors
+	d.decode(rv.Elem(), d.Prefix, "", lookup, &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (d *Decoder) decode(rv reflect.Value, prefix, path string, lookup func(string) (string, bool), errs *Errors) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag, hasTag := field.Tag.Lookup("env")
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+
+		fv := rv.Field(i)
+		if isNullable(field.Type) {
+			if !hasTag {
+				continue
+			}
+			name := prefix + tag
+			raw, ok := lookup(name)
+			n := fv.Addr().Interface().(nullableField)
+			switch {
+			case !ok:
+				fv.Set(reflect.Zero(field.Type))
+			case raw == d.Null:
+				n.Clear()
+			default:
+				set := fv.Addr().MethodByName("Set")
+				value := reflect.New(set.Type().In(0)).Elem()
+				if err := textconv.Parse(raw, value); err != nil {
+					n.Clear()
+					*errs = append(*errs, &Error{Name: name, Field: fieldPath, Err: err})
+				} else {
+					set.Call([]reflect.Value{value})
+				}
+			}
+			continue
+		}
+
+		switch {
+		case field.Type.Kind() == reflect.Pointer && isNullable(field.Type.Elem()):
+			if hasTag {
+				*errs = append(*errs, &Error{Name: prefix + tag, Field: fieldPath, Err: fmt.Errorf("unsupported field type %v", field.Type)})
+			}
+		case hasTag && textconv.IsValue(field.Type):
+			*errs = append(*errs, &Error{Name: prefix + tag, Field: fieldPath, Err: fmt.Errorf("unsupported field type %v", field.Type)})
+		case field.Type.Kind() == reflect.Struct:
+			d.decode(fv, prefix+tag, fieldPath, lookup, errs)
+		case field.Type.Kind() == reflect.Pointer && field.Type.Elem().Kind() == reflect.Struct:
+			if fv.IsNil() {
+				fv.Set(reflect.New(field.Type.Elem()))
+			}
+			d.decode(fv.Elem(), prefix+tag, fieldPath, lookup, errs)
+		case hasTag:
+			*errs = append(*errs, &Error{Name: prefix + tag, Field: fieldPath, Err: fmt.Errorf("unsupported field type %v", field.Type)})
+		}
+	}
+}
+
+/*
+isNullable reports whether typ is a Nullable, whose Set method takes the type it holds.
+*/
+func isNullable(typ reflect.Type) bool {
+	ptr := reflect.PtrTo(typ)
+	if typ.Kind() != reflect.Struct || !ptr.Implements(nullableFieldType) {
+		return false
+	}
+	set, ok := ptr.MethodByName("Set")
+	return ok && set.Type.NumIn() == 2
+}
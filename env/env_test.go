@@ -0,0 +1,140 @@
+package env
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/missingsemi/nullable"
+)
+
+func lookupFrom(vars map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := vars[key]
+		return v, ok
+	}
+}
+
+func TestDecode(t *testing.T) {
+	{
+		type S struct {
+			Port nullable.Nullable[int] `env:"PORT"`
+		}
+		t.Setenv("PORT", "8080")
+		var s S
+		err := Decode(&s)
+		if err != nil {
+			t.Errorf("Decode(&s) = %v. Expected %v.", err, nil)
+		} else if s.Port.ValueOr(0) != 8080 {
+			t.Errorf("s.Port = %+v. Expected %v.", s.Port, 8080)
+		}
+	}
+	{
+		type Database struct {
+			URL     nullable.Nullable[string]        `env:"URL"`
+			Timeout nullable.Nullable[time.Duration] `env:"TIMEOUT"`
+		}
+		type S struct {
+			Name     nullable.Nullable[string] `env:"NAME"`
+			Debug    nullable.Nullable[bool]   `env:"DEBUG"`
+			Missing  nullable.Nullable[int]    `env:"MISSING"`
+			Skipped  nullable.Nullable[int]    `env:"-"`
+			Untagged nullable.Nullable[int]
+			Database Database  `env:"DB_"`
+			Replica  *Database `env:"REPLICA_"`
+		}
+		d := Decoder{
+			Prefix: "APP_",
+			LookupEnv: lookupFrom(map[string]string{
+				"APP_NAME":            "",
+				"APP_DEBUG":           "true",
+				"APP_DB_URL":          "postgres://localhost",
+				"APP_DB_TIMEOUT":      "5s",
+				"APP_REPLICA_TIMEOUT": "",
+			}),
+		}
+		s := S{Missing: nullable.From(1), Untagged: nullable.From(2)}
+		err := d.Decode(&s)
+		if err != nil {
+			t.Fatalf("d.Decode(&s) = %v. Expected %v.", err, nil)
+		}
+		if !s.Name.IsPresent() || !s.Name.IsNull() {
+			t.Errorf("s.Name = %+v. Expected present null.", s.Name)
+		}
+		if s.Debug.ValueOr(false) != true {
+			t.Errorf("s.Debug = %+v. Expected %v.", s.Debug, true)
+		}
+		if s.Missing.IsPresent() {
+			t.Error("s.Missing.IsPresent() = true. Expected false.")
+		}
+		if s.Untagged.ValueOr(0) != 2 {
+			t.Errorf("s.Untagged = %+v. Expected %v.", s.Untagged, 2)
+		}
+		if s.Database.URL.ValueOr("") != "postgres://localhost" {
+			t.Errorf("s.Database.URL = %+v. Expected %v.", s.Database.URL, "postgres://localhost")
+		}
+		if s.Database.Timeout.ValueOr(0) != 5*time.Second {
+			t.Errorf("s.Database.Timeout = %+v. Expected %v.", s.Database.Timeout, 5*time.Second)
+		}
+		if s.Replica == nil || s.Replica.URL.IsPresent() || !s.Replica.Timeout.IsNull() || !s.Replica.Timeout.IsPresent() {
+			t.Errorf("s.Replica = %+v. Expected absent URL and null Timeout.", s.Replica)
+		}
+	}
+	{
+		type S struct {
+			Name nullable.Nullable[string] `env:"NAME"`
+			Port nullable.Nullable[int]    `env:"PORT"`
+		}
+		d := Decoder{
+			Null:      "null",
+			LookupEnv: lookupFrom(map[string]string{"NAME": "", "PORT": "null"}),
+		}
+		var s S
+		err := d.Decode(&s)
+		if err != nil {
+			t.Errorf("d.Decode(&s) = %v. Expected %v.", err, nil)
+		}
+		if s.Name.ValueOr("-") != "" {
+			t.Errorf("s.Name = %+v. Expected %q.", s.Name, "")
+		}
+		if !s.Port.IsPresent() || !s.Port.IsNull() {
+			t.Errorf("s.Port = %+v. Expected present null.", s.Port)
+		}
+	}
+	{
+		type S struct {
+			Port    nullable.Nullable[int]  `env:"PORT"`
+			Debug   nullable.Nullable[bool] `env:"DEBUG"`
+			Plain   int                     `env:"PLAIN"`
+			Since   time.Time               `env:"SINCE"`
+			Retries *nullable.Nullable[int] `env:"RETRIES"`
+			Healthy nullable.Nullable[int]  `env:"HEALTHY"`
+		}
+		d := Decoder{
+			LookupEnv: lookupFrom(map[string]string{"PORT": "eighty", "DEBUG": "maybe", "RETRIES": "3", "HEALTHY": "1"}),
+		}
+		var s S
+		err := d.Decode(&s)
+		var errs Errors
+		if !errors.As(err, &errs) {
+			t.Fatalf("d.Decode(&s) = %v. Expected Errors.", err)
+		}
+		if len(errs) != 5 {
+			t.Errorf("len(errs) = %v. Expected %v.", len(errs), 5)
+		} else if errs[0].Name != "PORT" || errs[1].Field != "Debug" || errs[2].Name != "PLAIN" || errs[3].Name != "SINCE" || errs[4].Name != "RETRIES" {
+			t.Errorf("errs = %v. Expected PORT, DEBUG, PLAIN, SINCE and RETRIES.", errs)
+		}
+		if s.Retries != nil {
+			t.Errorf("s.Retries = %+v. Expected nil.", s.Retries)
+		}
+		if s.Healthy.ValueOr(0) != 1 {
+			t.Errorf("s.Healthy = %+v. Expected %v.", s.Healthy, 1)
+		}
+	}
+	{
+		var s struct{}
+		if err := Decode(s); err == nil {
+			t.Errorf("Decode(s) = %v. Expected error.", err)
+		}
+	}
+}
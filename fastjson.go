@@ -0,0 +1,215 @@
+package nullable
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+/*
+unmarshalJSONFast decodes raw without reflection if T is a primitive type, json.Number or time.Time, and reports whether it did.
+It only accepts input that encoding/json decodes to the same value, leaving the Nullable untouched otherwise so that the generic path reports any error.
+*/
+func (n *Nullable[T]) unmarshalJSONFast(raw []byte) bool {
+	if string(raw) == "null" {
+		n.ptr = nil
+		return true
+	}
+
+	switch p := any(n.ptr).(type) {
+	case *bool:
+		switch string(raw) {
+		case "true":
+			return storeFast(n, p, true)
+		case "false":
+			return storeFast(n, p, false)
+		}
+	case *string:
+		if s, ok := parseJSONStringFast(raw); ok {
+			return storeFast(n, p, s)
+		}
+	case *int:
+		if i, ok := parseJSONIntFast(raw, strconv.IntSize); ok {
+			return storeFast(n, p, int(i))
+		}
+	case *int8:
+		if i, ok := parseJSONIntFast(raw, 8); ok {
+			return storeFast(n, p, int8(i))
+		}
+	case *int16:
+		if i, ok := parseJSONIntFast(raw, 16); ok {
+			return storeFast(n, p, int16(i))
+		}
+	case *int32:
+		if i, ok := parseJSONIntFast(raw, 32); ok {
+			return storeFast(n, p, int32(i))
+		}
+	case *int64:
+		if i, ok := parseJSONIntFast(raw, 64); ok {
+			return storeFast(n, p, i)
+		}
+	case *uint:
+		if u, ok := parseJSONUintFast(raw, strconv.IntSize); ok {
+			return storeFast(n, p, uint(u))
+		}
+	case *uint8:
+		if u, ok := parseJSONUintFast(raw, 8); ok {
+			return storeFast(n, p, uint8(u))
+		}
+	case *uint16:
+		if u, ok := parseJSONUintFast(raw, 16); ok {
+			return storeFast(n, p, uint16(u))
+		}
+	case *uint32:
+		if u, ok := parseJSONUintFast(raw, 32); ok {
+			return storeFast(n, p, uint32(u))
+		}
+	case *uint64:
+		if u, ok := parseJSONUintFast(raw, 64); ok {
+			return storeFast(n, p, u)
+		}
+	case *float32:
+		if f, ok := parseJSONFloatFast(raw, 32); ok {
+			return storeFast(n, p, float32(f))
+		}
+	case *float64:
+		if f, ok := parseJSONFloatFast(raw, 64); ok {
+			return storeFast(n, p, f)
+		}
+	case *json.Number:
+		if isJSONNumber(raw) {
+			return storeFast(n, p, json.Number(raw))
+		}
+	case *time.Time:
+		var t time.Time
+		if len(raw) > 0 && raw[0] == '"' && t.UnmarshalJSON(raw) == nil {
+			return storeFast(n, p, t)
+		}
+	}
+	return false
+}
+
+/*
+storeFast stores v in n and reports true. p is n's pointer, which has the type *V because V is T.
+Like encoding/json, an existing value is overwritten in place rather than replaced.
+*/
+func storeFast[T, V any](n *Nullable[T], p *V, v V) bool {
+	if p == nil {
+		p = new(V)
+		n.ptr = any(p).(*T)
+	}
+	*p = v
+	return true
+}
+
+/*
+parseJSONStringFast returns the contents of raw if it is a quoted JSON string without escapes that holds valid UTF-8.
+*/
+func parseJSONStringFast(raw []byte) (string, bool) {
+	if len(raw) < 2 || raw[0] != '"' || raw[len(raw)-1] != '"' {
+		return "", false
+	}
+	raw = raw[1 : len(raw)-1]
+	for _, b := range raw {
+		if b < ' ' || b == '"' || b == '\\' {
+			return "", false
+		}
+	}
+	if !utf8.Valid(raw) {
+		return "", false
+	}
+	return string(raw), true
+}
+
+/*
+parseJSONIntFast parses raw if it is a JSON integer that fits in bitSize bits.
+*/
+func parseJSONIntFast(raw []byte, bitSize int) (int64, bool) {
+	if !isJSONInteger(raw) {
+		return 0, false
+	}
+	i, err := strconv.ParseInt(string(raw), 10, bitSize)
+	return i, err == nil
+}
+
+/*
+parseJSONUintFast parses raw if it is a non-negative JSON integer that fits in bitSize bits.
+*/
+func parseJSONUintFast(raw []byte, bitSize int) (uint64, bool) {
+	if len(raw) == 0 || raw[0] == '-' || !isJSONInteger(raw) {
+		return 0, false
+	}
+	u, err := strconv.ParseUint(string(raw), 10, bitSize)
+	return u, err == nil
+}
+
+/*
+parseJSONFloatFast parses raw if it is a JSON number within the range of a float of bitSize bits.
+*/
+func parseJSONFloatFast(raw []byte, bitSize int) (float64, bool) {
+	if !isJSONNumber(raw) {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(string(raw), bitSize)
+	return f, err == nil
+}
+
+/*
+isJSONInteger reports whether raw is a JSON number without a fraction or exponent.
+*/
+func isJSONInteger(raw []byte) bool {
+	for _, b := range raw {
+		if b == '.' || b == 'e' || b == 'E' {
+			return false
+		}
+	}
+	return isJSONNumber(raw)
+}
+
+/*
+isJSONNumber reports whether raw is exactly a number as defined by the JSON grammar.
+*/
+func isJSONNumber(raw []byte) bool {
+	if len(raw) > 0 && raw[0] == '-' {
+		raw = raw[1:]
+	}
+	if len(raw) == 0 {
+		return false
+	}
+
+	switch {
+	case raw[0] == '0':
+		raw = raw[1:]
+	case '1' <= raw[0] && raw[0] <= '9':
+		raw = skipDigits(raw[1:])
+	default:
+		return false
+	}
+
+	if len(raw) >= 2 && raw[0] == '.' && isDigit(raw[1]) {
+		raw = skipDigits(raw[2:])
+	}
+	if len(raw) >= 2 && (raw[0] == 'e' || raw[0] == 'E') {
+		raw = raw[1:]
+		if raw[0] == '+' || raw[0] == '-' {
+			raw = raw[1:]
+		}
+		if len(raw) == 0 || !isDigit(raw[0]) {
+			return false
+		}
+		raw = skipDigits(raw)
+	}
+	return len(raw) == 0
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+func skipDigits(raw []byte) []byte {
+	for len(raw) > 0 && isDigit(raw[0]) {
+		raw = raw[1:]
+	}
+	return raw
+}
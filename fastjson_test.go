@@ -0,0 +1,114 @@
+package nullable
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+/*
+checkUnmarshalJSONFast compares n.UnmarshalJSON with the generic encoding/json path.
+*/
+func checkUnmarshalJSONFast[T any](t *testing.T, raw []byte) {
+	t.Helper()
+	var fast, generic Nullable[T]
+	fastErr := fast.UnmarshalJSON(raw)
+	genericErr := generic.unmarshalJSONGeneric(raw)
+	if (fastErr == nil) != (genericErr == nil) || fastErr != nil && fastErr.Error() != genericErr.Error() {
+		t.Errorf("Nullable[%T].UnmarshalJSON(%q) err = %v. Expected %v.", *new(T), raw, fastErr, genericErr)
+		return
+	}
+	if !reflect.DeepEqual(fast.ptr, generic.ptr) {
+		t.Errorf("Nullable[%T].UnmarshalJSON(%q) = %v. Expected %v.", *new(T), raw, fast.ptr, generic.ptr)
+	}
+}
+
+func checkUnmarshalJSONFastAll(t *testing.T, raw []byte) {
+	t.Helper()
+	checkUnmarshalJSONFast[bool](t, raw)
+	checkUnmarshalJSONFast[string](t, raw)
+	checkUnmarshalJSONFast[int](t, raw)
+	checkUnmarshalJSONFast[int8](t, raw)
+	checkUnmarshalJSONFast[int16](t, raw)
+	checkUnmarshalJSONFast[int32](t, raw)
+	checkUnmarshalJSONFast[int64](t, raw)
+	checkUnmarshalJSONFast[uint](t, raw)
+	checkUnmarshalJSONFast[uint8](t, raw)
+	checkUnmarshalJSONFast[uint16](t, raw)
+	checkUnmarshalJSONFast[uint32](t, raw)
+	checkUnmarshalJSONFast[uint64](t, raw)
+	checkUnmarshalJSONFast[float32](t, raw)
+	checkUnmarshalJSONFast[float64](t, raw)
+	checkUnmarshalJSONFast[json.Number](t, raw)
+	checkUnmarshalJSONFast[time.Time](t, raw)
+}
+
+var unmarshalJSONFastSeeds = []string{
+	"null", " null", "true", "false", "tru", `"true"`,
+	"0", "-0", "12", "-12", "012", "1.5", "-1.5e3", "1e", "1.", ".5", "+1", "-",
+	"127", "128", "-129", "255", "256", "65536", "4294967296",
+	"9223372036854775807", "9223372036854775808", "18446744073709551615", "18446744073709551616",
+	"3.4e38", "3.5e38", "1e400", "1e-400",
+	`""`, `"hello"`, `"é"`, `"é"`, `"a\"b"`, "\"\xff\"", "\"\t\"", `"12"`, `"`,
+	`"2022-06-01T12:00:00Z"`, `"2022-06-01T12:00:00.123+01:30"`, `"2022-06-01"`, `"2022-06-01T12:00:00,5Z"`,
+	"[1]", `{"a":1}`, "", " 1", "1 ",
+}
+
+func TestUnmarshalJSONFast(t *testing.T) {
+	for _, raw := range unmarshalJSONFastSeeds {
+		checkUnmarshalJSONFastAll(t, []byte(raw))
+	}
+}
+
+func TestUnmarshalJSONFastInPlace(t *testing.T) {
+	n := From(10)
+	p := n.ptr
+	if err := n.UnmarshalJSON([]byte("20")); err != nil {
+		t.Fatalf("n.UnmarshalJSON(20) err = %v. Expected nil.", err)
+	}
+	if n.ptr != p || *p != 20 {
+		t.Errorf("n.UnmarshalJSON(20) = %v at %p. Expected 20 at %p.", *n.ptr, n.ptr, p)
+	}
+}
+
+func TestUnmarshalJSONFastAllocs(t *testing.T) {
+	var i Nullable[int]
+	var s Nullable[string]
+	rawInt, rawString := []byte("12345"), []byte(`"hello"`)
+	allocs := testing.AllocsPerRun(100, func() {
+		i.UnmarshalJSON(rawInt)
+		s.UnmarshalJSON(rawString)
+	})
+	// Only the string itself is allocated once the pointers exist.
+	if allocs > 1 {
+		t.Errorf("testing.AllocsPerRun(UnmarshalJSON) = %v. Expected at most %v.", allocs, 1)
+	}
+}
+
+func FuzzUnmarshalJSONFast(f *testing.F) {
+	for _, raw := range unmarshalJSONFastSeeds {
+		f.Add([]byte(raw))
+	}
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		checkUnmarshalJSONFastAll(t, raw)
+	})
+}
+
+func BenchmarkUnmarshalJSONFast(b *testing.B) {
+	var n Nullable[float64]
+	raw := []byte("1234.5678")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		n.UnmarshalJSON(raw)
+	}
+}
+
+func BenchmarkUnmarshalJSONGeneric(b *testing.B) {
+	var n Nullable[float64]
+	raw := []byte("1234.5678")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		n.unmarshalJSONGeneric(raw)
+	}
+}
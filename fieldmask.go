@@ -0,0 +1,274 @@
+package nullable
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+/*
+FieldMask returns the protobuf field mask paths of the present Nullable fields of the struct v, in field order.
+Each name is the field's json name, or its Go name without one, converted to snake_case; a fieldmask tag overrides it.
+Nested structs and pointers to structs add their name and a dot to the paths of their fields, while embedded structs add nothing.
+A present Nullable holding a struct is a single path, since its value replaces the whole message.
+
+	type UpdateUser struct {
+		DisplayName nullable.Nullable[string] `json:"displayName"`
+		Address     struct {
+			PostalCode nullable.Nullable[string] `json:"postalCode"`
+		} `json:"address"`
+	}
+
+	paths, _ := nullable.FieldMask(update)
+	request.UpdateMask = &fieldmaskpb.FieldMask{Paths: paths}
+	// [display_name address.postal_code]
+*/
+func FieldMask(v any) ([]string, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("FieldMask() called with a %T. Expected a struct", v)
+	}
+	paths := []string{}
+	fieldMask(addressable(rv), "", &paths)
+	return paths, nil
+}
+
+func fieldMask(rv reflect.Value, prefix string, paths *[]string) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name, ok := maskName(field)
+		if !ok {
+			continue
+		}
+		fv := rv.Field(i)
+		if n, ok := asReflector(fv); ok {
+			if n.IsPresent() {
+				*paths = append(*paths, prefix+name)
+			}
+			continue
+		}
+
+		if fv.Kind() == reflect.Pointer && fv.Type().Elem().Kind() == reflect.Struct {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Struct {
+			fieldMask(fv, maskPrefix(prefix, name, field), paths)
+		}
+	}
+}
+
+/*
+FromFieldMask sets the Nullable fields of the struct pointed to by dst from the fields of src with the same Go name, for every field selected by paths.
+Selected fields are present, holding the value of src's field, and all other fields are absent.
+A path naming a nested struct selects all of its fields.
+src is a struct or pointer to a struct, such as a protobuf message, and its fields may be Nullables, values of the same type or pointers to them.
+Nil pointers and null or absent Nullables in src make the selected field null, as do fields missing from src.
+Paths that don't name a field of dst are reported as an error.
+
+	var update UpdateUser
+	err := nullable.FromFieldMask(&update, request.User, request.UpdateMask.Paths)
+*/
+func FromFieldMask(dst any, src any, paths []string) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return errors.New("FromFieldMask() called with a non-pointer or nil destination. Expected a pointer to a struct")
+	}
+
+	sv := reflect.ValueOf(src)
+	for sv.Kind() == reflect.Pointer && !sv.IsNil() {
+		sv = sv.Elem()
+	}
+	if sv.Kind() == reflect.Struct {
+		sv = addressable(sv)
+	} else if src != nil && sv.Kind() != reflect.Pointer {
+		return fmt.Errorf("FromFieldMask() called with a source of type %T. Expected a struct", src)
+	} else {
+		sv = reflect.Value{}
+	}
+
+	mask := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		mask[path] = false
+	}
+	if err := fromFieldMask(dv.Elem(), sv, "", false, mask); err != nil {
+		return err
+	}
+
+	var unknown []string
+	for _, path := range paths {
+		if !mask[path] {
+			unknown = append(unknown, path)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("FromFieldMask() called with unknown paths %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+/*
+fromFieldMask walks dv, selecting fields whose path is in mask or that are inside a selected struct.
+Paths are marked true in mask when they are found.
+*/
+func fromFieldMask(dv, sv reflect.Value, prefix string, selected bool, mask map[string]bool) error {
+	rt := dv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name, ok := maskName(field)
+		if !ok {
+			continue
+		}
+		path := prefix + name
+		fieldSelected := selected
+		if _, ok := mask[path]; ok && !field.Anonymous {
+			mask[path] = true
+			fieldSelected = true
+		}
+
+		fv := dv.Field(i)
+		var sf reflect.Value
+		if sv.IsValid() {
+			if sourceField, ok := sv.Type().FieldByName(field.Name); ok {
+				if sf, _ = sv.FieldByIndexErr(sourceField.Index); sf.IsValid() && !sf.CanInterface() {
+					sf = reflect.Value{}
+				}
+			}
+		}
+
+		if n, ok := asReflector(fv); ok {
+			fv.Set(reflect.Zero(fv.Type()))
+			if fieldSelected {
+				if err := setFromMaskSource(n, sf); err != nil {
+					return fmt.Errorf("cannot set %s: %w", path, err)
+				}
+			}
+			continue
+		}
+
+		// Structs that nothing selects are still walked so that their Nullables are made absent,
+		// but nil pointers to them are only allocated when something inside may be selected.
+		nestedPrefix := maskPrefix(prefix, name, field)
+		if fv.Kind() == reflect.Pointer && fv.Type().Elem().Kind() == reflect.Struct {
+			if fv.IsNil() {
+				if !fv.CanSet() || !fieldSelected && !maskHasPrefix(mask, nestedPrefix) {
+					continue
+				}
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() != reflect.Struct {
+			continue
+		}
+		for sf.IsValid() && sf.Kind() == reflect.Pointer {
+			if sf.IsNil() {
+				sf = reflect.Value{}
+				break
+			}
+			sf = sf.Elem()
+		}
+		if sf.IsValid() && sf.Kind() != reflect.Struct {
+			sf = reflect.Value{}
+		}
+		if err := fromFieldMask(fv, sf, nestedPrefix, fieldSelected, mask); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+setFromMaskSource sets n from sf, which is invalid if the source has no value for the field.
+*/
+func setFromMaskSource(n reflector, sf reflect.Value) error {
+	if !sf.IsValid() {
+		n.Clear()
+		return nil
+	}
+	if src, ok := asReflector(addressable(sf)); ok {
+		sf = src.reflectValue()
+	}
+
+	elem := n.elemType()
+	for sf.IsValid() && sf.Kind() == reflect.Pointer && !sf.Type().AssignableTo(elem) {
+		if sf.IsNil() {
+			sf = reflect.Value{}
+			break
+		}
+		sf = sf.Elem()
+	}
+	switch {
+	case !sf.IsValid():
+		n.Clear()
+	case sf.Type().AssignableTo(elem):
+		n.setReflect(sf)
+	default:
+		return fmt.Errorf("source field of type %v is not assignable to %v", sf.Type(), elem)
+	}
+	return nil
+}
+
+/*
+maskName returns the field mask name of field, or false if it is skipped.
+*/
+func maskName(field reflect.StructField) (string, bool) {
+	if name, ok := field.Tag.Lookup("fieldmask"); ok {
+		return name, name != "-" && field.IsExported()
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" || !field.IsExported() {
+		return "", false
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return snakeCase(name), true
+}
+
+/*
+maskPrefix returns the prefix of the paths of the fields of a nested struct field.
+Embedded structs without an explicit name promote their fields.
+*/
+func maskPrefix(prefix, name string, field reflect.StructField) string {
+	if field.Anonymous && field.Tag.Get("json") == "" && field.Tag.Get("fieldmask") == "" {
+		return prefix
+	}
+	return prefix + name + "."
+}
+
+func maskHasPrefix(mask map[string]bool, prefix string) bool {
+	for path := range mask {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+snakeCase converts a camelCase or PascalCase name to snake_case, keeping acronyms together, so that HTTPServer becomes http_server.
+*/
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,146 @@
+package nullable
+
+import (
+	"reflect"
+	"testing"
+)
+
+type maskAddress struct {
+	City       Nullable[string] `json:"city"`
+	PostalCode Nullable[string] `json:"postalCode"`
+}
+
+type MaskAudit struct {
+	UpdatedBy Nullable[string]
+}
+
+type maskUser struct {
+	MaskAudit
+	DisplayName Nullable[string]      `json:"displayName"`
+	Age         Nullable[int32]       `json:"age"`
+	HTTPProxy   Nullable[string]      `json:",omitempty"`
+	Address     maskAddress           `json:"address"`
+	Billing     *maskAddress          `json:"billing"`
+	Home        Nullable[maskAddress] `json:"home"`
+	Tags        Nullable[[]string]    `fieldmask:"labels"`
+	Secret      Nullable[string]      `json:"-"`
+	Count       int
+}
+
+func TestSnakeCase(t *testing.T) {
+	if got := snakeCase("displayName"); got != "display_name" {
+		t.Errorf("snakeCase(%s) = %s. Expected %s.", "displayName", got, "display_name")
+	}
+	if got := snakeCase("DisplayName"); got != "display_name" {
+		t.Errorf("snakeCase(%s) = %s. Expected %s.", "DisplayName", got, "display_name")
+	}
+	if got := snakeCase("HTTPProxy"); got != "http_proxy" {
+		t.Errorf("snakeCase(%s) = %s. Expected %s.", "HTTPProxy", got, "http_proxy")
+	}
+	if got := snakeCase("userID"); got != "user_id" {
+		t.Errorf("snakeCase(%s) = %s. Expected %s.", "userID", got, "user_id")
+	}
+	if got := snakeCase("ipv4Address"); got != "ipv4_address" {
+		t.Errorf("snakeCase(%s) = %s. Expected %s.", "ipv4Address", got, "ipv4_address")
+	}
+	if got := snakeCase("already_set"); got != "already_set" {
+		t.Errorf("snakeCase(%s) = %s. Expected %s.", "already_set", got, "already_set")
+	}
+	if got := snakeCase("ID"); got != "id" {
+		t.Errorf("snakeCase(%s) = %s. Expected %s.", "ID", got, "id")
+	}
+}
+
+func TestFieldMask(t *testing.T) {
+	user := maskUser{
+		MaskAudit:   MaskAudit{UpdatedBy: From("admin")},
+		DisplayName: From("Ann"),
+		HTTPProxy:   Null[string](),
+		Address:     maskAddress{PostalCode: From("0150")},
+		Billing:     &maskAddress{City: Null[string]()},
+		Home:        From(maskAddress{}),
+		Tags:        From([]string{"a"}),
+		Secret:      From("x"),
+		Count:       1,
+	}
+	paths, err := FieldMask(&user)
+	if err != nil {
+		t.Fatalf("FieldMask() err = %v. Expected nil.", err)
+	}
+	expected := []string{"updated_by", "display_name", "http_proxy", "address.postal_code", "billing.city", "home", "labels"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("FieldMask() = %v. Expected %v.", paths, expected)
+	}
+
+	if paths, err := FieldMask(maskUser{}); err != nil || len(paths) != 0 {
+		t.Errorf("FieldMask(empty) = %v, %v. Expected [], nil.", paths, err)
+	}
+	if _, err := FieldMask(1); err == nil {
+		t.Errorf("FieldMask(1) err = nil. Expected an error.")
+	}
+}
+
+func TestFromFieldMask(t *testing.T) {
+	// A message as generated by protoc-gen-go, with proto3 optional fields as pointers.
+	type message struct {
+		DisplayName string
+		Age         *int32
+		HTTPProxy   *string
+		Address     *struct {
+			City       string
+			PostalCode string
+		}
+		Home *maskAddress
+		Tags []string
+	}
+	age := int32(30)
+	src := &message{DisplayName: "Ann", Age: &age, Tags: []string{"a"}}
+	src.Address = &struct {
+		City       string
+		PostalCode string
+	}{City: "Oslo", PostalCode: "0150"}
+
+	dst := maskUser{DisplayName: From("old"), Count: 1}
+	err := FromFieldMask(&dst, src, []string{"display_name", "age", "http_proxy", "address", "billing.city", "labels"})
+	if err != nil {
+		t.Fatalf("FromFieldMask() err = %v. Expected nil.", err)
+	}
+	if dst.DisplayName.ValueOrDefault() != "Ann" || dst.Age.ValueOrDefault() != 30 || !dst.HTTPProxy.IsPresent() || !dst.HTTPProxy.IsNull() {
+		t.Errorf("FromFieldMask() = %+v.", dst)
+	}
+	if dst.Address.City.ValueOrDefault() != "Oslo" || dst.Address.PostalCode.ValueOrDefault() != "0150" {
+		t.Errorf("FromFieldMask() Address = %+v.", dst.Address)
+	}
+	if dst.Billing == nil || !dst.Billing.City.IsPresent() || !dst.Billing.City.IsNull() || dst.Billing.PostalCode.IsPresent() {
+		t.Errorf("FromFieldMask() Billing = %+v.", dst.Billing)
+	}
+	if !reflect.DeepEqual(dst.Tags.ValueOrDefault(), []string{"a"}) || dst.Home.IsPresent() || dst.UpdatedBy.IsPresent() || dst.Count != 1 {
+		t.Errorf("FromFieldMask() = %+v.", dst)
+	}
+
+	var fromNullable maskUser
+	err = FromFieldMask(&fromNullable, maskUser{DisplayName: From("Bob"), Age: From[int32](1)}, []string{"display_name", "home"})
+	if err != nil || fromNullable.DisplayName.ValueOrDefault() != "Bob" || !fromNullable.Home.IsNull() || fromNullable.Age.IsPresent() {
+		t.Errorf("FromFieldMask(Nullable source) = %+v, %v.", fromNullable, err)
+	}
+
+	populated := maskUser{
+		DisplayName: From("old"),
+		Address:     maskAddress{City: From("Bergen")},
+		Billing:     &maskAddress{PostalCode: From("5003")},
+	}
+	err = FromFieldMask(&populated, src, []string{"display_name"})
+	if err != nil || populated.DisplayName.ValueOrDefault() != "Ann" || populated.Address.City.IsPresent() || populated.Billing.PostalCode.IsPresent() {
+		t.Errorf("FromFieldMask(populated) = %+v, %v. Expected unselected nested fields to be absent.", populated, err)
+	}
+
+	if err := FromFieldMask(&fromNullable, src, []string{"display_name", "nope"}); err == nil {
+		t.Errorf("FromFieldMask() with an unknown path err = nil. Expected an error.")
+	}
+	if err := FromFieldMask(&fromNullable, struct{ Age string }{"x"}, []string{"age"}); err == nil {
+		t.Errorf("FromFieldMask() with a mismatched type err = nil. Expected an error.")
+	}
+	if err := FromFieldMask(fromNullable, src, nil); err == nil {
+		t.Errorf("FromFieldMask() with a non-pointer err = nil. Expected an error.")
+	}
+}
@@ -0,0 +1,89 @@
+package nullable
+
+import (
+	"flag"
+	"reflect"
+)
+
+/*
+flagNull is the flag value that marks a Nullable as null.
+*/
+const flagNull = "null"
+
+/*
+flagValue adapts a Nullable to the flag.Getter interface.
+Nullable can't implement flag.Value itself because its Set method already takes a T.
+*/
+type flagValue[T any] struct {
+	n *Nullable[T]
+}
+
+/*
+FlagValue returns a flag.Getter that stores parsed flag values in n.
+The flag is parsed with the UnmarshalText method of T if it has one, with strconv for primitive types and as JSON otherwise, except that the value "null" makes n null.
+The returned value also implements IsBoolFlag, so a Nullable[bool] flag can be passed without a value.
+This is useful for flag libraries other than the standard flag package; otherwise see FlagVar.
+*/
+func FlagValue[T any](n *Nullable[T]) flag.Getter {
+	return &flagValue[T]{n}
+}
+
+/*
+FlagVar defines a flag with the specified name and usage string that stores its value in n.
+The Nullable stays absent unless the flag is passed, and can be made null by passing -name=null.
+Use flag.CommandLine as fs to define a flag on the default command line.
+
+	var verbose nullable.Nullable[bool]
+	var limit nullable.Nullable[int]
+	nullable.FlagVar(flag.CommandLine, &verbose, "verbose", "log verbosely")
+	nullable.FlagVar(flag.CommandLine, &limit, "limit", "maximum number of results")
+	flag.Parse()
+
+	limit.IsPresent() // false unless -limit was passed
+
+*/
+func FlagVar[T any](fs *flag.FlagSet, n *Nullable[T], name string, usage string) {
+	fs.Var(FlagValue(n), name, usage)
+}
+
+/*
+String implements the flag.Value interface.
+*/
+func (f *flagValue[T]) String() string {
+	if f.n == nil || !f.n.present {
+		return ""
+	}
+	if f.n.ptr == nil {
+		return flagNull
+	}
+	text, err := f.n.marshalText()
+	if err != nil {
+		return ""
+	}
+	return text
+}
+
+/*
+Set implements the flag.Value interface.
+*/
+func (f *flagValue[T]) Set(value string) error {
+	if value == flagNull {
+		f.n.Clear()
+		return nil
+	}
+	return f.n.unmarshalText(value)
+}
+
+/*
+Get implements the flag.Getter interface by returning a copy of the Nullable.
+*/
+func (f *flagValue[T]) Get() any {
+	return *f.n
+}
+
+/*
+IsBoolFlag reports whether T is a boolean, allowing the flag package to accept the flag without a value.
+*/
+func (f *flagValue[T]) IsBoolFlag() bool {
+	return reflect.TypeOf((*T)(nil)).Elem().Kind() == reflect.Bool
+}
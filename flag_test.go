@@ -0,0 +1,88 @@
+package nullable
+
+import (
+	"flag"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestFlagVar(t *testing.T) {
+	{
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		var limit Nullable[int]
+		var name Nullable[string]
+		var verbose Nullable[bool]
+		var timeout Nullable[time.Duration]
+		FlagVar(fs, &limit, "limit", "")
+		FlagVar(fs, &name, "name", "")
+		FlagVar(fs, &verbose, "verbose", "")
+		FlagVar(fs, &timeout, "timeout", "")
+		err := fs.Parse([]string{"-limit=10", "-verbose", "-name=null"})
+		if err != nil {
+			t.Fatalf("fs.Parse(...) = %v. Expected %v.", err, nil)
+		}
+		if limit.ptr == nil || *limit.ptr != 10 {
+			t.Errorf("limit = %+v. Expected %v.", limit, 10)
+		}
+		if verbose.ptr == nil || *verbose.ptr != true {
+			t.Errorf("verbose = %+v. Expected %v.", verbose, true)
+		}
+		if name.ptr != nil || name.present == false {
+			t.Errorf("name = %+v. Expected present null.", name)
+		}
+		if timeout.present == true {
+			t.Error("timeout.IsPresent() = true. Expected false.")
+		}
+	}
+	{
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		var limit Nullable[int]
+		FlagVar(fs, &limit, "limit", "")
+		if err := fs.Parse([]string{"-limit=ten"}); err == nil {
+			t.Errorf("fs.Parse(-limit=ten) = %v. Expected error.", err)
+		}
+		fs.PrintDefaults()
+	}
+	{
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		var limit Nullable[int]
+		FlagVar(fs, &limit, "limit", "")
+		if err := fs.Parse([]string{"-limit"}); err == nil {
+			t.Errorf("fs.Parse(-limit) = %v. Expected error.", err)
+		}
+	}
+}
+
+func TestFlagValue(t *testing.T) {
+	{
+		var n Nullable[int]
+		f := FlagValue(&n)
+		if got := f.String(); got != "" {
+			t.Errorf("f.String() = %q. Expected %q.", got, "")
+		}
+		f.Set("null")
+		if got := f.String(); got != "null" {
+			t.Errorf("f.String() = %q. Expected %q.", got, "null")
+		}
+		f.Set("5")
+		if got := f.String(); got != "5" {
+			t.Errorf("f.String() = %q. Expected %q.", got, "5")
+		}
+		if got := f.Get().(Nullable[int]); got.ptr == nil || *got.ptr != 5 {
+			t.Errorf("f.Get() = %+v. Expected %v.", got, 5)
+		}
+	}
+	{
+		var b Nullable[bool]
+		var s Nullable[string]
+		if got := FlagValue(&b).(interface{ IsBoolFlag() bool }).IsBoolFlag(); got != true {
+			t.Errorf("IsBoolFlag() = %v. Expected %v.", got, true)
+		}
+		if got := FlagValue(&s).(interface{ IsBoolFlag() bool }).IsBoolFlag(); got != false {
+			t.Errorf("IsBoolFlag() = %v. Expected %v.", got, false)
+		}
+	}
+}
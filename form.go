@@ -0,0 +1,318 @@
+package nullable
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/missingsemi/nullable/internal/textconv"
+)
+
+/*
+FieldError describes a single key that couldn't be decoded into its field.
+*/
+type FieldError struct {
+	Key   string
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s (%s): %v", e.Key, e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+/*
+FieldErrors holds every FieldError encountered while decoding a struct.
+*/
+type FieldErrors []*FieldError
+
+func (e FieldErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+/*
+defaultMaxMemory matches the limit used by http.Request.FormValue for multipart bodies.
+*/
+const defaultMaxMemory = 32 << 20
+
+/*
+Form holds the options used to decode and encode url.Values.
+The zero value is ready to use.
+*/
+type Form struct {
+	// Null is the value that marks a field as null. The default is the empty string.
+	Null string
+	// MaxMemory is passed to http.Request.ParseMultipartForm. The default is 32 MB.
+	MaxMemory int64
+}
+
+/*
+DecodeForm decodes values into the struct pointed to by v using a zero Form.
+*/
+func DecodeForm(values url.Values, v any) error {
+	return Form{}.Decode(values, v)
+}
+
+/*
+EncodeForm encodes the struct v into url.Values using a zero Form.
+*/
+func EncodeForm(v any) (url.Values, error) {
+	return Form{}.Encode(v)
+}
+
+/*
+Decode populates the Nullable fields of the struct pointed to by v from the keys named by their form tags.
+Fields whose key is missing are marked absent, keys whose only value is the null token are marked null, and other values are parsed with the same rules as FlagValue.
+Nullables of slices, except []byte, collect every value of a repeated key.
+
+	type Filter struct {
+		Name  nullable.Nullable[string]    `form:"name"`
+		Since nullable.Nullable[time.Time] `form:"since"`
+		IDs   nullable.Nullable[[]int]     `form:"id"`
+	}
+
+	var filter Filter
+	values, _ := url.ParseQuery("name=&id=1&id=2")
+	nullable.DecodeForm(values, &filter)
+	filter.Name.IsNull()     // true
+	filter.Since.IsAbsent()  // true
+	filter.IDs.Value()       // []int{1, 2}
+
+Nested structs and pointers to structs are decoded recursively, with their form tag followed by a dot added to the prefix of their keys.
+Decoding continues past invalid values, and all of them are reported together as FieldErrors.
+*/
+func (f Form) Decode(values url.Values, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("Decode() called with a non-pointer to struct")
+	}
+
+	var errs FieldErrors
+	f.decode(values, rv.Elem(), "", "", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+/*
+DecodeRequest decodes the query string and body of r into the struct pointed to by v.
+Bodies encoded as application/x-www-form-urlencoded or multipart/form-data are parsed, and their values take precedence over the query string.
+*/
+func (f Form) DecodeRequest(r *http.Request, v any) error {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		maxMemory := f.MaxMemory
+		if maxMemory == 0 {
+			maxMemory = defaultMaxMemory
+		}
+		if err := r.ParseMultipartForm(maxMemory); err != nil {
+			return err
+		}
+	} else if err := r.ParseForm(); err != nil {
+		return err
+	}
+	return f.Decode(r.Form, v)
+}
+
+func (f Form) decode(values url.Values, rv reflect.Value, prefix, path string, errs *FieldErrors) {
+	walkTagged(rv, "form", path, true, func(fv reflect.Value, tag, fieldPath string, n reflector) {
+		if n == nil {
+			f.decode(values, fv, formPrefix(prefix, tag), fieldPath, errs)
+			return
+		}
+
+		key := prefix + tag
+		vals, ok := values[key]
+		switch {
+		case !ok || len(vals) == 0:
+			fv.Set(reflect.Zero(fv.Type()))
+		case len(vals) == 1 && vals[0] == f.Null:
+			n.Clear()
+		default:
+			if err := setTexts(n, vals); err != nil {
+				n.Clear()
+				*errs = append(*errs, &FieldError{Key: key, Field: fieldPath, Err: err})
+			}
+		}
+	}, func(tag, fieldPath string, typ reflect.Type) {
+		*errs = append(*errs, &FieldError{Key: prefix + tag, Field: fieldPath, Err: fmt.Errorf("unsupported field type %v", typ)})
+	})
+}
+
+/*
+Encode encodes the present Nullable fields of the struct v into url.Values, using the keys named by their form tags.
+Absent fields are omitted, null fields are encoded as the null token, and slices are encoded as repeated keys.
+Empty slices have no value to repeat, so they are also encoded as the null token, which keeps them present but decodes them as null.
+*/
+func (f Form) Encode(v any) (url.Values, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New("Encode() called with a non-struct")
+	}
+	rv = addressable(rv)
+
+	values := url.Values{}
+	var errs FieldErrors
+	f.encode(values, rv, "", "", &errs)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return values, nil
+}
+
+func (f Form) encode(values url.Values, rv reflect.Value, prefix, path string, errs *FieldErrors) {
+	walkTagged(rv, "form", path, false, func(fv reflect.Value, tag, fieldPath string, n reflector) {
+		if n == nil {
+			f.encode(values, fv, formPrefix(prefix, tag), fieldPath, errs)
+			return
+		}
+
+		key := prefix + tag
+		if !n.IsPresent() {
+			return
+		}
+		if n.IsNull() {
+			values.Set(key, f.Null)
+			return
+		}
+		texts, err := getTexts(n)
+		switch {
+		case err != nil:
+			*errs = append(*errs, &FieldError{Key: key, Field: fieldPath, Err: err})
+		case len(texts) == 0:
+			values.Set(key, f.Null)
+		default:
+			values[key] = texts
+		}
+	}, nil)
+}
+
+/*
+formPrefix returns the prefix of the keys of a nested struct.
+*/
+func formPrefix(prefix, tag string) string {
+	if tag == "" {
+		return prefix
+	}
+	return prefix + tag + "."
+}
+
+/*
+walkTagged calls visit for every exported field of rv that is either a Nullable with the given tag or a nested struct.
+Nested structs are passed with a nil reflector and are left for visit to recurse into.
+Nil pointers to structs are allocated if allocate is true and skipped otherwise.
+Tagged fields of any other type, including pointers to Nullables and structs that implement encoding.TextUnmarshaler, are passed to unsupported, if it isn't nil.
+*/
+func walkTagged(rv reflect.Value, key, path string, allocate bool, visit func(fv reflect.Value, tag, fieldPath string, n reflector), unsupported func(tag, fieldPath string, typ reflect.Type)) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag, hasTag := field.Tag.Lookup(key)
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+
+		fv := rv.Field(i)
+		if n, ok := asReflector(fv); ok {
+			if hasTag {
+				visit(fv, tag, fieldPath, n)
+			}
+			continue
+		}
+
+		switch {
+		case field.Type.Kind() == reflect.Pointer && reflect.PtrTo(field.Type.Elem()).Implements(reflectorType):
+			if hasTag && unsupported != nil {
+				unsupported(tag, fieldPath, field.Type)
+			}
+		case hasTag && textconv.IsValue(field.Type):
+			if unsupported != nil {
+				unsupported(tag, fieldPath, field.Type)
+			}
+		case field.Type.Kind() == reflect.Struct:
+			visit(fv, tag, fieldPath, nil)
+		case field.Type.Kind() == reflect.Pointer && field.Type.Elem().Kind() == reflect.Struct:
+			if fv.IsNil() {
+				if !allocate {
+					continue
+				}
+				fv.Set(reflect.New(field.Type.Elem()))
+			}
+			visit(fv.Elem(), tag, fieldPath, nil)
+		case hasTag && unsupported != nil:
+			unsupported(tag, fieldPath, field.Type)
+		}
+	}
+}
+
+/*
+isRepeated reports whether values of typ are encoded as one value per element.
+*/
+func isRepeated(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Slice && typ.Elem().Kind() != reflect.Uint8
+}
+
+/*
+setTexts parses texts into n, collecting every text if n holds a slice and using the first text otherwise.
+*/
+func setTexts(n reflector, texts []string) error {
+	typ := n.elemType()
+	val := reflect.New(typ).Elem()
+	if isRepeated(typ) {
+		val.Set(reflect.MakeSlice(typ, len(texts), len(texts)))
+		for i, text := range texts {
+			if err := textconv.Parse(text, val.Index(i)); err != nil {
+				return err
+			}
+		}
+	} else if err := textconv.Parse(texts[0], val); err != nil {
+		return err
+	}
+	n.setReflect(val)
+	return nil
+}
+
+/*
+getTexts is the inverse of setTexts.
+n must hold a value.
+*/
+func getTexts(n reflector) ([]string, error) {
+	val := n.reflectValue()
+	if !isRepeated(val.Type()) {
+		text, err := textconv.Format(val)
+		if err != nil {
+			return nil, err
+		}
+		return []string{text}, nil
+	}
+
+	texts := make([]string, val.Len())
+	for i := range texts {
+		text, err := textconv.Format(val.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		texts[i] = text
+	}
+	return texts, nil
+}
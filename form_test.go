@@ -0,0 +1,207 @@
+package nullable
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeForm(t *testing.T) {
+	{
+		type S struct {
+			Name    Nullable[string]    `form:"name"`
+			Limit   Nullable[int]       `form:"limit"`
+			Since   Nullable[time.Time] `form:"since"`
+			IDs     Nullable[[]int]     `form:"id"`
+			Missing Nullable[bool]      `form:"missing"`
+			Page    struct {
+				Size Nullable[int] `form:"size"`
+			} `form:"page"`
+			Untagged struct {
+				Sort Nullable[string] `form:"sort"`
+			}
+		}
+		values, _ := url.ParseQuery("name=&limit=10&since=2022-06-01T00:00:00Z&id=1&id=2&page.size=20&sort=asc")
+		s := S{Missing: From(true)}
+		err := DecodeForm(values, &s)
+		if err != nil {
+			t.Fatalf("DecodeForm(values, &s) = %v. Expected %v.", err, nil)
+		}
+		if s.Name.ptr != nil || s.Name.present == false {
+			t.Errorf("s.Name = %+v. Expected present null.", s.Name)
+		}
+		if s.Limit.ptr == nil || *s.Limit.ptr != 10 {
+			t.Errorf("s.Limit = %+v. Expected %v.", s.Limit, 10)
+		}
+		if want := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC); s.Since.ptr == nil || !s.Since.ptr.Equal(want) {
+			t.Errorf("s.Since = %+v. Expected %v.", s.Since, want)
+		}
+		if s.IDs.ptr == nil || !reflect.DeepEqual(*s.IDs.ptr, []int{1, 2}) {
+			t.Errorf("s.IDs = %+v. Expected %v.", s.IDs, []int{1, 2})
+		}
+		if s.Missing.present == true {
+			t.Error("s.Missing.IsPresent() = true. Expected false.")
+		}
+		if s.Page.Size.ptr == nil || *s.Page.Size.ptr != 20 {
+			t.Errorf("s.Page.Size = %+v. Expected %v.", s.Page.Size, 20)
+		}
+		if s.Untagged.Sort.ptr == nil || *s.Untagged.Sort.ptr != "asc" {
+			t.Errorf("s.Untagged.Sort = %+v. Expected %v.", s.Untagged.Sort, "asc")
+		}
+	}
+	{
+		type S struct {
+			Name  Nullable[string] `form:"name"`
+			Limit Nullable[int]    `form:"limit"`
+		}
+		values, _ := url.ParseQuery("name=&limit=null")
+		var s S
+		err := Form{Null: "null"}.Decode(values, &s)
+		if err != nil {
+			t.Fatalf("Decode(values, &s) = %v. Expected %v.", err, nil)
+		}
+		if s.Name.ptr == nil || *s.Name.ptr != "" {
+			t.Errorf("s.Name = %+v. Expected %q.", s.Name, "")
+		}
+		if s.Limit.ptr != nil || s.Limit.present == false {
+			t.Errorf("s.Limit = %+v. Expected present null.", s.Limit)
+		}
+	}
+	{
+		type S struct {
+			Limit Nullable[int]   `form:"limit"`
+			IDs   Nullable[[]int] `form:"id"`
+			Plain int             `form:"plain"`
+			Since time.Time       `form:"since"`
+			Page  *Nullable[int]  `form:"page"`
+		}
+		values, _ := url.ParseQuery("limit=ten&id=1&id=two&plain=1&since=2022-06-01T12:00:00Z&page=2")
+		var s S
+		err := DecodeForm(values, &s)
+		var errs FieldErrors
+		if !errors.As(err, &errs) {
+			t.Fatalf("DecodeForm(values, &s) = %v. Expected FieldErrors.", err)
+		}
+		if len(errs) != 5 || errs[0].Key != "limit" || errs[1].Key != "id" || errs[2].Field != "Plain" || errs[3].Key != "since" || errs[4].Key != "page" {
+			t.Errorf("errs = %v. Expected limit, id, plain, since and page.", errs)
+		}
+		if s.Page != nil {
+			t.Errorf("s.Page = %+v. Expected nil.", s.Page)
+		}
+	}
+}
+
+func TestDecodeRequest(t *testing.T) {
+	type S struct {
+		Name  Nullable[string] `form:"name"`
+		Limit Nullable[int]    `form:"limit"`
+	}
+	{
+		r := httptest.NewRequest(http.MethodGet, "/?name=&limit=5", nil)
+		var s S
+		if err := (Form{}).DecodeRequest(r, &s); err != nil {
+			t.Fatalf("DecodeRequest(r, &s) = %v. Expected %v.", err, nil)
+		}
+		if s.Name.ptr != nil || s.Name.present == false {
+			t.Errorf("s.Name = %+v. Expected present null.", s.Name)
+		}
+		if s.Limit.ptr == nil || *s.Limit.ptr != 5 {
+			t.Errorf("s.Limit = %+v. Expected %v.", s.Limit, 5)
+		}
+	}
+	{
+		r := httptest.NewRequest(http.MethodPost, "/?limit=5", strings.NewReader("name=bob&limit=6"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		var s S
+		if err := (Form{}).DecodeRequest(r, &s); err != nil {
+			t.Fatalf("DecodeRequest(r, &s) = %v. Expected %v.", err, nil)
+		}
+		if s.Name.ptr == nil || *s.Name.ptr != "bob" {
+			t.Errorf("s.Name = %+v. Expected %v.", s.Name, "bob")
+		}
+		if s.Limit.ptr == nil || *s.Limit.ptr != 6 {
+			t.Errorf("s.Limit = %+v. Expected %v.", s.Limit, 6)
+		}
+	}
+	{
+		var body bytes.Buffer
+		w := multipart.NewWriter(&body)
+		w.WriteField("limit", "7")
+		w.Close()
+		r := httptest.NewRequest(http.MethodPost, "/", &body)
+		r.Header.Set("Content-Type", w.FormDataContentType())
+		var s S
+		if err := (Form{}).DecodeRequest(r, &s); err != nil {
+			t.Fatalf("DecodeRequest(r, &s) = %v. Expected %v.", err, nil)
+		}
+		if s.Name.present == true {
+			t.Error("s.Name.IsPresent() = true. Expected false.")
+		}
+		if s.Limit.ptr == nil || *s.Limit.ptr != 7 {
+			t.Errorf("s.Limit = %+v. Expected %v.", s.Limit, 7)
+		}
+	}
+}
+
+func TestEncodeForm(t *testing.T) {
+	{
+		type Page struct {
+			Size Nullable[int] `form:"size"`
+		}
+		type S struct {
+			Name   Nullable[string]    `form:"name"`
+			Limit  Nullable[int]       `form:"limit"`
+			Since  Nullable[time.Time] `form:"since"`
+			IDs    Nullable[[]int]     `form:"id"`
+			Tags   Nullable[[]string]  `form:"tag"`
+			Absent Nullable[bool]      `form:"absent"`
+			Page   Page                `form:"page"`
+			Nil    *Page               `form:"nil"`
+		}
+		s := S{
+			Name:  Null[string](),
+			Limit: From(10),
+			Since: From(time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)),
+			IDs:   From([]int{1, 2}),
+			Tags:  From([]string{}),
+			Page:  Page{Size: From(20)},
+		}
+		got, err := EncodeForm(s)
+		if err != nil {
+			t.Fatalf("EncodeForm(s) = %v. Expected %v.", err, nil)
+		}
+		want := url.Values{
+			"name":      {""},
+			"limit":     {"10"},
+			"since":     {"2022-06-01T00:00:00Z"},
+			"id":        {"1", "2"},
+			"tag":       {""},
+			"page.size": {"20"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("EncodeForm(s) = %v. Expected %v.", got, want)
+		}
+		if s.Nil != nil {
+			t.Errorf("s.Nil = %+v. Expected %v.", s.Nil, nil)
+		}
+
+		var roundTrip S
+		if err := DecodeForm(got, &roundTrip); err != nil {
+			t.Errorf("DecodeForm(got, &roundTrip) = %v. Expected %v.", err, nil)
+		} else if roundTrip.Name.present == false || roundTrip.Absent.present == true || *roundTrip.Limit.ptr != 10 || roundTrip.Tags.present == false {
+			t.Errorf("roundTrip = %+v. Expected %+v.", roundTrip, s)
+		}
+	}
+	{
+		if _, err := EncodeForm(10); err == nil {
+			t.Errorf("EncodeForm(10) = %v. Expected error.", err)
+		}
+	}
+}
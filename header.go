@@ -0,0 +1,132 @@
+package nullable
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"reflect"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+/*
+DecodeHeader populates the Nullable fields of the struct pointed to by v from the headers named by their header tags.
+Fields whose header is missing are marked absent, headers that are present but empty are marked null, and other values are parsed with the same rules as FlagValue.
+Nullable[time.Time] fields are parsed with http.ParseTime, and Nullables of slices, except []byte, collect every value of a repeated header.
+
+	type Preconditions struct {
+		IfMatch           nullable.Nullable[string]    `header:"If-Match"`
+		IfUnmodifiedSince nullable.Nullable[time.Time] `header:"If-Unmodified-Since"`
+		Priority          nullable.Nullable[int]       `header:"X-Request-Priority"`
+	}
+
+	var preconditions Preconditions
+	err := nullable.DecodeHeader(r.Header, &preconditions)
+
+Nested structs and pointers to structs are decoded recursively.
+Decoding continues past invalid values, and all of them are reported together as FieldErrors.
+*/
+func DecodeHeader(h http.Header, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("DecodeHeader() called with a non-pointer to struct")
+	}
+
+	var errs FieldErrors
+	decodeHeader(h, rv.Elem(), "", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func decodeHeader(h http.Header, rv reflect.Value, path string, errs *FieldErrors) {
+	walkTagged(rv, "header", path, true, func(fv reflect.Value, tag, fieldPath string, n reflector) {
+		if n == nil {
+			decodeHeader(h, fv, fieldPath, errs)
+			return
+		}
+
+		key := textproto.CanonicalMIMEHeaderKey(tag)
+		vals, ok := h[key]
+		switch {
+		case !ok || len(vals) == 0:
+			fv.Set(reflect.Zero(fv.Type()))
+		case len(vals) == 1 && vals[0] == "":
+			n.Clear()
+		case n.elemType() == timeType:
+			t, err := http.ParseTime(vals[0])
+			if err != nil {
+				n.Clear()
+				*errs = append(*errs, &FieldError{Key: key, Field: fieldPath, Err: err})
+				return
+			}
+			n.setReflect(reflect.ValueOf(t))
+		default:
+			if err := setTexts(n, vals); err != nil {
+				n.Clear()
+				*errs = append(*errs, &FieldError{Key: key, Field: fieldPath, Err: err})
+			}
+		}
+	}, func(tag, fieldPath string, typ reflect.Type) {
+		*errs = append(*errs, &FieldError{Key: tag, Field: fieldPath, Err: fmt.Errorf("unsupported field type %v", typ)})
+	})
+}
+
+/*
+EncodeHeader sets the headers named by the header tags of the present Nullable fields of the struct v.
+Absent fields are left unset, null fields are set to an empty value, and Nullable[time.Time] fields are formatted with http.TimeFormat.
+Nullables of slices, except []byte, are set as repeated headers.
+Empty slices have no value to repeat, so they are also set to an empty value, which keeps them present but decodes them as null.
+
+	req, _ := http.NewRequest(http.MethodPut, url, body)
+	err := nullable.EncodeHeader(req.Header, preconditions)
+
+*/
+func EncodeHeader(h http.Header, v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return errors.New("EncodeHeader() called with a non-struct")
+	}
+	rv = addressable(rv)
+
+	var errs FieldErrors
+	encodeHeader(h, rv, "", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func encodeHeader(h http.Header, rv reflect.Value, path string, errs *FieldErrors) {
+	walkTagged(rv, "header", path, false, func(fv reflect.Value, tag, fieldPath string, n reflector) {
+		if n == nil {
+			encodeHeader(h, fv, fieldPath, errs)
+			return
+		}
+
+		key := textproto.CanonicalMIMEHeaderKey(tag)
+		switch {
+		case !n.IsPresent():
+		case n.IsNull():
+			h[key] = []string{""}
+		case n.elemType() == timeType:
+			h[key] = []string{n.reflectValue().Interface().(time.Time).UTC().Format(http.TimeFormat)}
+		default:
+			texts, err := getTexts(n)
+			switch {
+			case err != nil:
+				*errs = append(*errs, &FieldError{Key: key, Field: fieldPath, Err: err})
+			case len(texts) == 0:
+				h[key] = []string{""}
+			default:
+				h[key] = texts
+			}
+		}
+	}, nil)
+}
@@ -0,0 +1,126 @@
+package nullable
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDecodeHeader(t *testing.T) {
+	{
+		type S struct {
+			IfMatch  Nullable[string]    `header:"If-Match"`
+			Since    Nullable[time.Time] `header:"If-Unmodified-Since"`
+			Priority Nullable[int]       `header:"x-request-priority"`
+			Tenant   Nullable[string]    `header:"X-Tenant"`
+			Accept   Nullable[[]string]  `header:"Accept"`
+			Missing  Nullable[string]    `header:"X-Missing"`
+		}
+		h := http.Header{}
+		h.Set("If-Match", `"abc"`)
+		h.Set("If-Unmodified-Since", "Wed, 01 Jun 2022 12:00:00 GMT")
+		h.Set("X-Request-Priority", "3")
+		h.Set("X-Tenant", "")
+		h.Add("Accept", "text/html")
+		h.Add("Accept", "application/json")
+		s := S{Missing: From("stale")}
+		err := DecodeHeader(h, &s)
+		if err != nil {
+			t.Fatalf("DecodeHeader(h, &s) = %v. Expected %v.", err, nil)
+		}
+		if s.IfMatch.ptr == nil || *s.IfMatch.ptr != `"abc"` {
+			t.Errorf("s.IfMatch = %+v. Expected %v.", s.IfMatch, `"abc"`)
+		}
+		if want := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC); s.Since.ptr == nil || !s.Since.ptr.Equal(want) {
+			t.Errorf("s.Since = %+v. Expected %v.", s.Since, want)
+		}
+		if s.Priority.ptr == nil || *s.Priority.ptr != 3 {
+			t.Errorf("s.Priority = %+v. Expected %v.", s.Priority, 3)
+		}
+		if s.Tenant.ptr != nil || s.Tenant.present == false {
+			t.Errorf("s.Tenant = %+v. Expected present null.", s.Tenant)
+		}
+		if want := []string{"text/html", "application/json"}; s.Accept.ptr == nil || !reflect.DeepEqual(*s.Accept.ptr, want) {
+			t.Errorf("s.Accept = %+v. Expected %v.", s.Accept, want)
+		}
+		if s.Missing.present == true {
+			t.Error("s.Missing.IsPresent() = true. Expected false.")
+		}
+	}
+	{
+		type S struct {
+			Since    Nullable[time.Time] `header:"If-Unmodified-Since"`
+			Priority Nullable[int]       `header:"X-Request-Priority"`
+		}
+		h := http.Header{}
+		h.Set("If-Unmodified-Since", "yesterday")
+		h.Set("X-Request-Priority", "high")
+		var s S
+		err := DecodeHeader(h, &s)
+		var errs FieldErrors
+		if !errors.As(err, &errs) {
+			t.Fatalf("DecodeHeader(h, &s) = %v. Expected FieldErrors.", err)
+		}
+		if len(errs) != 2 || errs[0].Key != "If-Unmodified-Since" || errs[1].Key != "X-Request-Priority" {
+			t.Errorf("errs = %v. Expected If-Unmodified-Since and X-Request-Priority.", errs)
+		}
+	}
+}
+
+func TestEncodeHeader(t *testing.T) {
+	{
+		type S struct {
+			IfMatch  Nullable[string]    `header:"If-Match"`
+			Since    Nullable[time.Time] `header:"If-Unmodified-Since"`
+			Priority Nullable[int]       `header:"x-request-priority"`
+			Tenant   Nullable[string]    `header:"X-Tenant"`
+			Accept   Nullable[[]string]  `header:"Accept"`
+			Missing  Nullable[string]    `header:"X-Missing"`
+		}
+		s := &S{
+			IfMatch:  From(`"abc"`),
+			Since:    From(time.Date(2022, 6, 1, 14, 0, 0, 0, time.FixedZone("CEST", 2*60*60))),
+			Priority: From(3),
+			Tenant:   Null[string](),
+			Accept:   From([]string{"text/html", "application/json"}),
+		}
+		h := http.Header{}
+		err := EncodeHeader(h, s)
+		if err != nil {
+			t.Fatalf("EncodeHeader(h, s) = %v. Expected %v.", err, nil)
+		}
+		want := http.Header{
+			"If-Match":            {`"abc"`},
+			"If-Unmodified-Since": {"Wed, 01 Jun 2022 12:00:00 GMT"},
+			"X-Request-Priority":  {"3"},
+			"X-Tenant":            {""},
+			"Accept":              {"text/html", "application/json"},
+		}
+		if !reflect.DeepEqual(h, want) {
+			t.Errorf("h = %v. Expected %v.", h, want)
+		}
+	}
+	{
+		type S struct {
+			Accept Nullable[[]string] `header:"Accept"`
+		}
+		h := http.Header{}
+		if err := EncodeHeader(h, S{Accept: From([]string{})}); err != nil {
+			t.Fatalf("EncodeHeader(h, s) = %v. Expected %v.", err, nil)
+		}
+		if got := h["Accept"]; !reflect.DeepEqual(got, []string{""}) {
+			t.Errorf("h[\"Accept\"] = %q. Expected %q.", got, []string{""})
+		}
+		var s S
+		if err := DecodeHeader(h, &s); err != nil || !s.Accept.IsPresent() || !s.Accept.IsNull() {
+			t.Errorf("DecodeHeader(h, &s) = %v, %+v. Expected a present null.", err, s.Accept)
+		}
+	}
+	{
+		if err := EncodeHeader(http.Header{}, "header"); err == nil {
+			t.Errorf("EncodeHeader(h, \"header\") = %v. Expected error.", err)
+		}
+	}
+}
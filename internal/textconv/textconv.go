@@ -0,0 +1,122 @@
+/*
+Package textconv converts values to and from the text of environment variables, flags, forms, headers and default tags.
+*/
+package textconv
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+)
+
+/*
+IsValue reports whether typ, or a pointer to it, implements encoding.TextUnmarshaler.
+Struct walkers use it to tell types such as time.Time, which are values rather than nested structs, from the structs they recurse into.
+Only Nullable fields hold decoded values, so walkers report tagged fields of these types as unsupported.
+*/
+func IsValue(typ reflect.Type) bool {
+	return typ.Implements(textUnmarshalerType) || reflect.PtrTo(typ).Implements(textUnmarshalerType)
+}
+
+/*
+Parse parses text into dst, which must be addressable.
+Types implementing encoding.TextUnmarshaler parse themselves, primitive kinds are parsed with strconv and everything else is parsed as JSON.
+*/
+func Parse(text string, dst reflect.Value) error {
+	if dst.Addr().Type().Implements(textUnmarshalerType) {
+		return dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text))
+	}
+
+	if dst.Type() == durationType {
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return err
+		}
+		dst.SetInt(int64(d))
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(text, 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(text, 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetFloat(f)
+	case reflect.Pointer:
+		elem := reflect.New(dst.Type().Elem())
+		if err := Parse(text, elem.Elem()); err != nil {
+			return err
+		}
+		dst.Set(elem)
+	default:
+		return json.Unmarshal([]byte(text), dst.Addr().Interface())
+	}
+	return nil
+}
+
+/*
+Format is the inverse of Parse.
+*/
+func Format(src reflect.Value) (string, error) {
+	if src.CanAddr() && src.Addr().Type().Implements(textMarshalerType) {
+		src = src.Addr()
+	}
+	if src.Type().Implements(textMarshalerType) {
+		text, err := src.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+
+	if src.Type() == durationType {
+		return time.Duration(src.Int()).String(), nil
+	}
+
+	switch src.Kind() {
+	case reflect.String:
+		return src.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(src.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(src.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(src.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(src.Float(), 'g', -1, src.Type().Bits()), nil
+	case reflect.Pointer:
+		if src.IsNil() {
+			return "", nil
+		}
+		return Format(src.Elem())
+	default:
+		raw, err := json.Marshal(src.Interface())
+		return string(raw), err
+	}
+}
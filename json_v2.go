@@ -0,0 +1,63 @@
+//go:build go1.25 && goexperiment.jsonv2
+
+package nullable
+
+import (
+	"encoding/json"
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+	"errors"
+)
+
+/*
+isV1 reports whether opts come from a call to encoding/json rather than encoding/json/v2.
+*/
+func isV1(opts jsonv2.Options) bool {
+	legacy, _ := jsonv2.GetOption(opts, json.ReportErrorsWithLegacySemantics)
+	return legacy
+}
+
+/*
+MarshalJSONTo implements the json.MarshalerTo interface of encoding/json/v2.
+It writes directly to the encoder instead of buffering through json.Marshal, and the encoder's options apply to the held value.
+Like MarshalJSON, whether or not the Nullable is marked as present has no effect.
+With the omitzero option, absent Nullables are omitted because they are the zero value.
+Calls made by encoding/json are deferred to MarshalJSON so that its behavior is unchanged.
+*/
+func (n Nullable[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if isV1(enc.Options()) {
+		return errors.ErrUnsupported
+	}
+	if n.ptr == nil {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return jsonv2.MarshalEncode(enc, n.ptr)
+}
+
+/*
+UnmarshalJSONFrom implements the json.UnmarshalerFrom interface of encoding/json/v2.
+It reads directly from the decoder, and the decoder's options apply to the held value.
+Like UnmarshalJSON, calls to UnmarshalJSONFrom always mark the Nullable as present.
+Errors are returned as reported by encoding/json/v2, which already include the JSON pointer of the offending value.
+Calls made by encoding/json are deferred to UnmarshalJSON so that its behavior is unchanged.
+*/
+func (n *Nullable[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if isV1(dec.Options()) {
+		return errors.ErrUnsupported
+	}
+	n.present = true
+	n.defaulted = false
+	if dec.PeekKind() == 'n' {
+		n.ptr = nil
+		_, err := dec.ReadToken()
+		return err
+	}
+
+	var tmp T
+	if err := jsonv2.UnmarshalDecode(dec, &tmp); err != nil {
+		n.ptr = nil
+		return err
+	}
+	n.ptr = &tmp
+	return nil
+}
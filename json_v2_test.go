@@ -0,0 +1,191 @@
+//go:build go1.25 && goexperiment.jsonv2
+
+package nullable
+
+import (
+	"bytes"
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestMarshalJSONTo(t *testing.T) {
+	{
+		type S struct {
+			Value  Nullable[int]    `json:"value"`
+			Null   Nullable[string] `json:"null"`
+			Absent Nullable[bool]   `json:"absent,omitzero"`
+		}
+		got, err := jsonv2.Marshal(S{Value: From(10), Null: Null[string]()})
+		if err != nil {
+			t.Errorf("jsonv2.Marshal(s) err = %v. Expected nil.", err)
+		} else if string(got) != `{"value":10,"null":null}` {
+			t.Errorf("jsonv2.Marshal(s) = %s. Expected %s.", got, `{"value":10,"null":null}`)
+		}
+	}
+	{
+		got, err := jsonv2.Marshal(From(10), jsonv2.StringifyNumbers(true))
+		if err != nil {
+			t.Errorf("jsonv2.Marshal(n) err = %v. Expected nil.", err)
+		} else if string(got) != `"10"` {
+			t.Errorf("jsonv2.Marshal(n) = %s. Expected %s.", got, `"10"`)
+		}
+	}
+	{
+		got, err := jsonv2.Marshal(From([]int(nil)), jsonv2.FormatNilSliceAsNull(true))
+		if err != nil {
+			t.Errorf("jsonv2.Marshal(n) err = %v. Expected nil.", err)
+		} else if string(got) != `null` {
+			t.Errorf("jsonv2.Marshal(n) = %s. Expected %s.", got, `null`)
+		}
+	}
+}
+
+func TestUnmarshalJSONFrom(t *testing.T) {
+	type S struct {
+		Value  Nullable[int]    `json:"value"`
+		Null   Nullable[string] `json:"null"`
+		Absent Nullable[bool]   `json:"absent"`
+	}
+	{
+		var s S
+		err := jsonv2.Unmarshal([]byte(`{"value": 10, "null": null}`), &s)
+		if err != nil {
+			t.Fatalf("jsonv2.Unmarshal(j, &s) = %v. Expected %v.", err, nil)
+		}
+		if s.Value.ptr == nil || *s.Value.ptr != 10 || s.Value.present == false {
+			t.Errorf("s.Value = %+v. Expected %v.", s.Value, 10)
+		}
+		if s.Null.ptr != nil || s.Null.present == false {
+			t.Errorf("s.Null = %+v. Expected present null.", s.Null)
+		}
+		if s.Absent.present == true {
+			t.Error("s.Absent.IsPresent() = true. Expected false.")
+		}
+	}
+	{
+		var s S
+		err := jsonv2.Unmarshal([]byte(`{"value": "10"}`), &s, jsonv2.StringifyNumbers(true))
+		if err != nil {
+			t.Errorf("jsonv2.Unmarshal(j, &s) = %v. Expected %v.", err, nil)
+		} else if s.Value.ptr == nil || *s.Value.ptr != 10 {
+			t.Errorf("s.Value = %+v. Expected %v.", s.Value, 10)
+		}
+	}
+	{
+		var s S
+		err := jsonv2.Unmarshal([]byte(`{"value": true}`), &s)
+		var semanticErr *jsonv2.SemanticError
+		if err == nil {
+			t.Errorf("jsonv2.Unmarshal(j, &s) = %v. Expected error.", err)
+		} else if !errors.As(err, &semanticErr) || semanticErr.JSONPointer != "/value" {
+			t.Errorf("jsonv2.Unmarshal(j, &s) = %v. Expected error at /value.", err)
+		}
+		if s.Value.ptr != nil || s.Value.present == false {
+			t.Errorf("s.Value = %+v. Expected present null.", s.Value)
+		}
+	}
+}
+
+/*
+v1Only hides the v2 methods of a Nullable so benchmarks can measure the MarshalJSON and UnmarshalJSON path.
+*/
+type v1Only[T any] struct {
+	n Nullable[T]
+}
+
+func (v v1Only[T]) MarshalJSON() ([]byte, error) {
+	return v.n.MarshalJSON()
+}
+
+func (v *v1Only[T]) UnmarshalJSON(raw []byte) error {
+	return v.n.UnmarshalJSON(raw)
+}
+
+type benchmarkRecord struct {
+	ID     Nullable[int64]   `json:"id"`
+	Name   Nullable[string]  `json:"name"`
+	Score  Nullable[float64] `json:"score"`
+	Active Nullable[bool]    `json:"active"`
+	Note   Nullable[string]  `json:"note"`
+}
+
+type benchmarkRecordV1 struct {
+	ID     v1Only[int64]   `json:"id"`
+	Name   v1Only[string]  `json:"name"`
+	Score  v1Only[float64] `json:"score"`
+	Active v1Only[bool]    `json:"active"`
+	Note   v1Only[string]  `json:"note"`
+}
+
+func benchmarkPayload() []benchmarkRecord {
+	records := make([]benchmarkRecord, 1000)
+	for i := range records {
+		records[i] = benchmarkRecord{
+			ID:     From(int64(i)),
+			Name:   From("record " + strconv.Itoa(i)),
+			Score:  From(float64(i) / 3),
+			Active: From(i%2 == 0),
+			Note:   Null[string](),
+		}
+	}
+	return records
+}
+
+func benchmarkPayloadV1() []benchmarkRecordV1 {
+	records := make([]benchmarkRecordV1, 1000)
+	for i, record := range benchmarkPayload() {
+		records[i] = benchmarkRecordV1{
+			ID:     v1Only[int64]{record.ID},
+			Name:   v1Only[string]{record.Name},
+			Score:  v1Only[float64]{record.Score},
+			Active: v1Only[bool]{record.Active},
+			Note:   v1Only[string]{record.Note},
+		}
+	}
+	return records
+}
+
+func BenchmarkMarshalJSON(b *testing.B) {
+	records := benchmarkPayloadV1()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := jsonv2.Marshal(records); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSONTo(b *testing.B) {
+	records := benchmarkPayload()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := jsonv2.Marshal(records); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalJSON(b *testing.B) {
+	data, _ := jsonv2.Marshal(benchmarkPayload())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var records []benchmarkRecordV1
+		if err := jsonv2.UnmarshalDecode(jsontext.NewDecoder(bytes.NewReader(data)), &records); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalJSONFrom(b *testing.B) {
+	data, _ := jsonv2.Marshal(benchmarkPayload())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var records []benchmarkRecord
+		if err := jsonv2.UnmarshalDecode(jsontext.NewDecoder(bytes.NewReader(data)), &records); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
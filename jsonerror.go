@@ -0,0 +1,264 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+/*
+maxErrorRaw is the number of bytes of offending JSON kept by an UnmarshalError.
+*/
+const maxErrorRaw = 64
+
+/*
+UnmarshalError is returned by UnmarshalJSON when the JSON can't be decoded into the Nullable's type.
+It wraps the error returned by encoding/json, so errors.As can still be used to find a *json.UnmarshalTypeError or *json.SyntaxError.
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		// Existing handling keeps working.
+	}
+
+Path locates the offending value within the JSON given to the Nullable, such as $[3].price for a Nullable[[]Item].
+It is relative to the Nullable rather than to the document being decoded, because UnmarshalJSON is only given the Nullable's own JSON, so $ is the Nullable itself whichever field holds it.
+When a Nullable nested inside another Nullable fails, the error of the innermost Nullable is returned with the location of that Nullable added to the front of its Path.
+*/
+type UnmarshalError struct {
+	// Path is a JSON path relative to the Nullable's value.
+	Path string
+	// Raw holds the offending JSON, truncated to 64 bytes.
+	Raw []byte
+	// Type is the type the Nullable holds.
+	Type reflect.Type
+	// Err is the error returned by encoding/json.
+	Err error
+
+	truncated bool
+}
+
+func (e *UnmarshalError) Error() string {
+	raw := string(e.Raw)
+	if e.truncated {
+		raw += "..."
+	}
+	return fmt.Sprintf("cannot unmarshal %s into %v at %s: %v", raw, e.Type, e.Path, e.Err)
+}
+
+func (e *UnmarshalError) Unwrap() error {
+	return e.Err
+}
+
+/*
+newUnmarshalError wraps err, which was returned while decoding raw into typ, in an UnmarshalError.
+*/
+func newUnmarshalError(raw []byte, typ reflect.Type, err error) error {
+	var nested *UnmarshalError
+	if errors.As(err, &nested) {
+		// Copy the error so that the one returned by the nested Nullable is left as it was.
+		located := *nested
+		located.Path = nestedPath(raw, typ) + strings.TrimPrefix(nested.Path, "$")
+		return &located
+	}
+
+	var offset int64 = -1
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &typeErr) {
+		offset = typeErr.Offset
+	} else if errors.As(err, &syntaxErr) {
+		offset = syntaxErr.Offset
+	}
+
+	path, value := "$", raw
+	if offset >= 0 {
+		path, value = locateJSON(raw, offset)
+	}
+	truncated := len(value) > maxErrorRaw
+	if truncated {
+		value = value[:maxErrorRaw]
+	}
+
+	return &UnmarshalError{
+		Path:      path,
+		Raw:       append([]byte(nil), value...),
+		Type:      typ,
+		Err:       err,
+		truncated: truncated,
+	}
+}
+
+/*
+nestedPath returns the path of the first value in raw that fails to decode into its part of typ and holds a type with its own UnmarshalJSON method.
+Values are decoded again one at a time, which only happens once decoding has already failed.
+*/
+func nestedPath(raw []byte, typ reflect.Type) string {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if reflect.PtrTo(typ).Implements(jsonUnmarshalerType) {
+		return "$"
+	}
+
+	fails := func(value []byte, typ reflect.Type) bool {
+		return json.Unmarshal(value, reflect.New(typ).Interface()) != nil
+	}
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array:
+		var elems []json.RawMessage
+		if json.Unmarshal(raw, &elems) != nil {
+			return "$"
+		}
+		for i, elem := range elems {
+			if fails(elem, typ.Elem()) {
+				return "$[" + strconv.Itoa(i) + "]" + strings.TrimPrefix(nestedPath(elem, typ.Elem()), "$")
+			}
+		}
+	case reflect.Map, reflect.Struct:
+		keys, values, ok := jsonMembers(raw)
+		if !ok {
+			return "$"
+		}
+		for i, key := range keys {
+			elemType, ok := memberType(typ, key)
+			if ok && fails(values[i], elemType) {
+				return "$" + jsonPathKey(key) + strings.TrimPrefix(nestedPath(values[i], elemType), "$")
+			}
+		}
+	}
+	return "$"
+}
+
+/*
+memberType returns the type that the object member named key decodes into when the object is decoded into typ, which is a map or a struct.
+*/
+func memberType(typ reflect.Type, key string) (reflect.Type, bool) {
+	if typ.Kind() == reflect.Map {
+		return typ.Elem(), true
+	}
+	fields := jsonFields(typ)
+	for _, field := range fields {
+		if field.name == key {
+			return field.typ, true
+		}
+	}
+	for _, field := range fields {
+		if strings.EqualFold(field.name, key) {
+			return field.typ, true
+		}
+	}
+	return nil, false
+}
+
+/*
+jsonMembers returns the keys and values of the JSON object raw in document order.
+*/
+func jsonMembers(raw []byte) ([]string, []json.RawMessage, bool) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, nil, false
+	}
+	var keys []string
+	var values []json.RawMessage
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, false
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, false
+		}
+		keys = append(keys, tok.(string))
+		values = append(values, value)
+	}
+	return keys, values, true
+}
+
+/*
+jsonFrame tracks the position within an array or object while locating a value.
+*/
+type jsonFrame struct {
+	array  bool
+	index  int
+	key    string
+	hasKey bool
+}
+
+func jsonPath(stack []jsonFrame) string {
+	path := "$"
+	for _, frame := range stack {
+		if frame.array {
+			path += "[" + strconv.Itoa(frame.index) + "]"
+		} else {
+			path += jsonPathKey(frame.key)
+		}
+	}
+	return path
+}
+
+/*
+locateJSON returns the path of the value whose first token ends at or after offset, along with the bytes of that value.
+If raw is invalid before that point, the path reached so far is returned along with the rest of raw.
+*/
+func locateJSON(raw []byte, offset int64) (string, []byte) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	var stack []jsonFrame
+	for {
+		start := dec.InputOffset()
+		tok, err := dec.Token()
+		if err != nil {
+			return jsonPath(stack), bytes.TrimLeft(raw[start:], " \t\r\n,:")
+		}
+
+		if delim, ok := tok.(json.Delim); ok && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			if len(stack) > 0 {
+				stack[len(stack)-1].hasKey = false
+			}
+			continue
+		}
+		if len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if !top.array && !top.hasKey {
+				top.key, _ = tok.(string)
+				top.hasKey = true
+				continue
+			}
+			if top.array {
+				top.index++
+			}
+		}
+
+		if dec.InputOffset() >= offset {
+			return jsonPath(stack), jsonValueAt(raw[start:])
+		}
+
+		switch tok {
+		case json.Delim('['):
+			stack = append(stack, jsonFrame{array: true, index: -1})
+		case json.Delim('{'):
+			stack = append(stack, jsonFrame{})
+		default:
+			if len(stack) > 0 {
+				stack[len(stack)-1].hasKey = false
+			}
+		}
+	}
+}
+
+/*
+jsonValueAt returns the first JSON value in raw, skipping any separators before it.
+*/
+func jsonValueAt(raw []byte) []byte {
+	raw = bytes.TrimLeft(raw, " \t\r\n,:")
+	var value json.RawMessage
+	if err := json.NewDecoder(bytes.NewReader(raw)).Decode(&value); err != nil {
+		return raw
+	}
+	return value
+}
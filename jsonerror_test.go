@@ -0,0 +1,114 @@
+package nullable
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnmarshalError(t *testing.T) {
+	type Item struct {
+		Name  string  `json:"name"`
+		Price float64 `json:"price"`
+	}
+	{
+		type S struct {
+			Items Nullable[[]Item] `json:"items"`
+		}
+		var s S
+		err := json.Unmarshal([]byte(`{"items": [{"name": "a", "price": 1}, {"name": "b", "price": "12.50"}]}`), &s)
+		var unmarshalErr *UnmarshalError
+		if !errors.As(err, &unmarshalErr) {
+			t.Fatalf("json.Unmarshal(j, &s) = %v. Expected *UnmarshalError.", err)
+		}
+		if unmarshalErr.Path != "$[1].price" {
+			t.Errorf("unmarshalErr.Path = %v. Expected %v.", unmarshalErr.Path, "$[1].price")
+		}
+		if string(unmarshalErr.Raw) != `"12.50"` {
+			t.Errorf("unmarshalErr.Raw = %s. Expected %s.", unmarshalErr.Raw, `"12.50"`)
+		}
+		if unmarshalErr.Type != reflect.TypeOf([]Item{}) {
+			t.Errorf("unmarshalErr.Type = %v. Expected %v.", unmarshalErr.Type, reflect.TypeOf([]Item{}))
+		}
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) {
+			t.Errorf("errors.As(err, &typeErr) = false. Expected true.")
+		}
+		if s.Items.ptr != nil || s.Items.present == false {
+			t.Errorf("s.Items = %+v. Expected present null.", s.Items)
+		}
+	}
+	{
+		var n Nullable[map[string][]int]
+		err := n.UnmarshalJSON([]byte(`{"a": [1, 2], "b c": [3, {"d": 4}]}`))
+		var unmarshalErr *UnmarshalError
+		if !errors.As(err, &unmarshalErr) {
+			t.Fatalf("n.UnmarshalJSON(j) = %v. Expected *UnmarshalError.", err)
+		}
+		if unmarshalErr.Path != `$["b c"][1]` {
+			t.Errorf("unmarshalErr.Path = %v. Expected %v.", unmarshalErr.Path, `$["b c"][1]`)
+		}
+		if string(unmarshalErr.Raw) != `{"d": 4}` {
+			t.Errorf("unmarshalErr.Raw = %s. Expected %s.", unmarshalErr.Raw, `{"d": 4}`)
+		}
+	}
+	{
+		var n Nullable[[]string]
+		long := `["` + strings.Repeat("a", 100) + `", 1]`
+		err := n.UnmarshalJSON([]byte(`[` + long + `]`))
+		var unmarshalErr *UnmarshalError
+		if !errors.As(err, &unmarshalErr) {
+			t.Fatalf("n.UnmarshalJSON(j) = %v. Expected *UnmarshalError.", err)
+		}
+		if unmarshalErr.Path != "$[0]" {
+			t.Errorf("unmarshalErr.Path = %v. Expected %v.", unmarshalErr.Path, "$[0]")
+		}
+		if len(unmarshalErr.Raw) != maxErrorRaw {
+			t.Errorf("len(unmarshalErr.Raw) = %v. Expected %v.", len(unmarshalErr.Raw), maxErrorRaw)
+		}
+		if !strings.Contains(err.Error(), "...") {
+			t.Errorf("err.Error() = %v. Expected truncated input.", err)
+		}
+	}
+	{
+		type Inner struct {
+			Count Nullable[int] `json:"count"`
+		}
+		var n Nullable[[]Inner]
+		err := n.UnmarshalJSON([]byte(`[{"count": 1}, {"count": true}]`))
+		var unmarshalErr *UnmarshalError
+		if !errors.As(err, &unmarshalErr) {
+			t.Fatalf("n.UnmarshalJSON(j) = %v. Expected *UnmarshalError.", err)
+		}
+		if unmarshalErr.Type != reflect.TypeOf(0) || unmarshalErr.Path != "$[1].count" || string(unmarshalErr.Raw) != "true" {
+			t.Errorf("unmarshalErr = %+v. Expected the innermost error at $[1].count.", unmarshalErr)
+		}
+	}
+	{
+		type Inner struct {
+			Tags Nullable[[]int] `json:"tags"`
+		}
+		var n Nullable[map[string]Nullable[Inner]]
+		err := n.UnmarshalJSON([]byte(`{"a": {"tags": [1]}, "b c": {"TAGS": [2, "x"]}}`))
+		var unmarshalErr *UnmarshalError
+		if !errors.As(err, &unmarshalErr) {
+			t.Fatalf("n.UnmarshalJSON(j) = %v. Expected *UnmarshalError.", err)
+		}
+		if unmarshalErr.Path != `$["b c"].TAGS[1]` || string(unmarshalErr.Raw) != `"x"` {
+			t.Errorf("unmarshalErr = %+v. Expected the innermost error at %s.", unmarshalErr, `$["b c"].TAGS[1]`)
+		}
+	}
+	{
+		nested := &UnmarshalError{Path: "$[1]", Raw: []byte(`"x"`), Type: reflect.TypeOf(0)}
+		err := newUnmarshalError([]byte(`[[1], [2, "x"]]`), reflect.TypeOf([]Nullable[[]int]{}), nested)
+		var unmarshalErr *UnmarshalError
+		if !errors.As(err, &unmarshalErr) || unmarshalErr.Path != "$[1][1]" {
+			t.Errorf("newUnmarshalError(j, typ, nested) = %v. Expected *UnmarshalError at %v.", err, "$[1][1]")
+		}
+		if nested.Path != "$[1]" {
+			t.Errorf("nested.Path = %v. Expected %v.", nested.Path, "$[1]")
+		}
+	}
+}
@@ -0,0 +1,304 @@
+package nullable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+/*
+PatchOp is a single operation of an RFC 6902 JSON Patch document.
+*/
+type PatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+/*
+PatchError is returned by ApplyJSONPatch when an operation can't be applied.
+*/
+type PatchError struct {
+	// Index is the position of the operation within the patch.
+	Index int
+	Op    PatchOp
+	Err   error
+}
+
+func (e *PatchError) Error() string {
+	return fmt.Sprintf("cannot apply JSON Patch operation %d (%s %s): %v", e.Index, e.Op.Op, e.Op.Path, e.Err)
+}
+
+func (e *PatchError) Unwrap() error {
+	return e.Err
+}
+
+/*
+patchField is implemented by a pointer to a Nullable or a RawNullable, the fields that JSON Patch operations target.
+*/
+type patchField interface {
+	IsPresent() bool
+	IsNull() bool
+	Clear()
+	json.Marshaler
+	json.Unmarshaler
+}
+
+/*
+JSONPatch holds the options used to generate RFC 6902 JSON Patch documents.
+The zero value is ready to use.
+*/
+type JSONPatch struct {
+	// RemoveNulls generates remove operations for null fields instead of replacing them with null.
+	RemoveNulls bool
+	// Add generates add operations for fields holding values instead of replace operations.
+	// Use it when the target may not hold those fields yet, since replace fails on an absent target.
+	Add bool
+}
+
+/*
+GenerateJSONPatch converts v into a JSON Patch document using a zero JSONPatch.
+*/
+func GenerateJSONPatch(v any) ([]PatchOp, error) {
+	return JSONPatch{}.Generate(v)
+}
+
+/*
+Generate converts v, a struct or pointer to a struct of Nullable fields, into a JSON Patch document.
+Absent fields generate no operation, null fields replace the target with null or remove it, and fields holding values replace or add the target.
+Paths are JSON Pointers built from the json tags of the fields, and nested structs and pointers to structs are walked recursively.
+Fields that are neither Nullable nor structs are ignored.
+
+	type UserPatch struct {
+		Name  nullable.Nullable[string] `json:"name"`
+		Email nullable.Nullable[string] `json:"email"`
+		Age   nullable.Nullable[int]    `json:"age"`
+	}
+
+	var patch UserPatch
+	json.Unmarshal([]byte(`{"name": "Ann", "email": null}`), &patch)
+	ops, _ := nullable.JSONPatch{RemoveNulls: true}.Generate(patch)
+	// [{"op":"replace","path":"/name","value":"Ann"},{"op":"remove","path":"/email"}]
+*/
+func (p JSONPatch) Generate(v any) ([]PatchOp, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, errors.New("JSONPatch.Generate() called with a nil pointer")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("JSONPatch.Generate() called with a %v. Expected a struct", rv.Type())
+	}
+	ops := []PatchOp{}
+	err := p.generate(addressable(rv), "", &ops)
+	return ops, err
+}
+
+func (p JSONPatch) generate(rv reflect.Value, path string, ops *[]PatchOp) error {
+	for _, field := range jsonFields(rv.Type()) {
+		fv, ok := fieldByIndex(rv, field.index, false)
+		if !ok || !fv.CanInterface() {
+			continue
+		}
+		fieldPath := path + "/" + jsonPointerToken(field.name)
+
+		f, ok := fv.Addr().Interface().(patchField)
+		if !ok {
+			if fv.Kind() == reflect.Pointer && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				if err := p.generate(fv, fieldPath, ops); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		switch {
+		case !f.IsPresent():
+		case f.IsNull() && p.RemoveNulls:
+			*ops = append(*ops, PatchOp{Op: "remove", Path: fieldPath})
+		default:
+			value, err := f.MarshalJSON()
+			if err != nil {
+				return fmt.Errorf("cannot marshal %s: %w", fieldPath, err)
+			}
+			op := "replace"
+			if p.Add && !f.IsNull() {
+				op = "add"
+			}
+			*ops = append(*ops, PatchOp{Op: op, Path: fieldPath, Value: value})
+		}
+	}
+	return nil
+}
+
+/*
+ApplyJSONPatch applies the JSON Patch document patch to the struct pointed to by v and returns the paths of the fields that became present, in the order they were first changed.
+Every path must lead to a Nullable field through the json names of nested structs, pointers to structs and Nullables holding structs.
+The add operation decodes its value into the field, even when it was absent.
+The replace operation does the same and remove makes the field null, but both fail on an absent field, as RFC 6902 requires their target to exist.
+The test operation compares the field's JSON with its value, and move and copy are not supported.
+Operations are applied in order, and the first one that fails stops the patch and is reported as a *PatchError.
+*/
+func ApplyJSONPatch(v any, patch []PatchOp) ([]string, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, errors.New("ApplyJSONPatch() called with a non-pointer or nil destination. Expected a pointer to a struct")
+	}
+
+	var changed []string
+	seen := map[string]bool{}
+	for i, op := range patch {
+		present, err := applyPatchOp(rv.Elem(), op)
+		if err != nil {
+			return changed, &PatchError{Index: i, Op: op, Err: err}
+		}
+		if present && !seen[op.Path] {
+			seen[op.Path] = true
+			changed = append(changed, op.Path)
+		}
+	}
+	return changed, nil
+}
+
+/*
+applyPatchOp applies op to rv and reports whether it made a field present.
+*/
+func applyPatchOp(rv reflect.Value, op PatchOp) (bool, error) {
+	// Only add may create its target, so only add allocates the structs leading to it.
+	f, err := resolvePatchPath(rv, op.Path, op.Op == "add")
+	if err != nil {
+		return false, err
+	}
+	wasPresent := f.IsPresent()
+
+	switch op.Op {
+	case "add", "replace":
+		if op.Op == "replace" && !wasPresent {
+			return false, errors.New("cannot replace an absent field")
+		}
+		if op.Value == nil {
+			return false, errors.New("missing value")
+		}
+		if err := f.UnmarshalJSON(op.Value); err != nil {
+			return false, err
+		}
+	case "remove":
+		if !wasPresent {
+			return false, errors.New("cannot remove an absent field")
+		}
+		f.Clear()
+	case "test":
+		if op.Value == nil {
+			return false, errors.New("missing value")
+		}
+		got, err := f.MarshalJSON()
+		if err != nil {
+			return false, err
+		}
+		var gotValue, expectedValue any
+		if err := json.Unmarshal(got, &gotValue); err != nil {
+			return false, err
+		}
+		if err := json.Unmarshal(op.Value, &expectedValue); err != nil {
+			return false, err
+		}
+		if !f.IsPresent() || !reflect.DeepEqual(gotValue, expectedValue) {
+			return false, fmt.Errorf("test failed: value is %s", got)
+		}
+		return false, nil
+	default:
+		return false, fmt.Errorf("unsupported operation %q", op.Op)
+	}
+	return !wasPresent, nil
+}
+
+/*
+resolvePatchPath returns the field at the JSON Pointer path within rv.
+If allocate is true, nil pointers to structs along the path are allocated.
+*/
+func resolvePatchPath(rv reflect.Value, path string, allocate bool) (patchField, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("invalid JSON Pointer %q", path)
+	}
+	tokens := strings.Split(path[1:], "/")
+	for i, token := range tokens {
+		name := strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+
+		var fv reflect.Value
+		found := false
+		for _, field := range jsonFields(rv.Type()) {
+			if field.name == name {
+				fv, found = fieldByIndex(rv, field.index, allocate)
+				break
+			}
+		}
+		if !found || !fv.CanInterface() {
+			return nil, fmt.Errorf("no field %q", name)
+		}
+
+		f, isField := fv.Addr().Interface().(patchField)
+		if i == len(tokens)-1 {
+			if !isField {
+				return nil, fmt.Errorf("field %q is not a Nullable", name)
+			}
+			return f, nil
+		}
+
+		if isField {
+			n, ok := asReflector(fv)
+			if !ok || n.IsNull() {
+				return nil, fmt.Errorf("field %q holds no value", name)
+			}
+			fv = n.reflectValue()
+		}
+		if fv.Kind() == reflect.Pointer && fv.Type().Elem().Kind() == reflect.Struct {
+			if fv.IsNil() {
+				if !allocate {
+					return nil, fmt.Errorf("field %q is nil", name)
+				}
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("field %q is not a struct", name)
+		}
+		rv = fv
+	}
+	return nil, fmt.Errorf("invalid JSON Pointer %q", path)
+}
+
+/*
+fieldByIndex returns the field of the struct rv at index, following pointers to embedded structs.
+If a pointer is nil it is allocated when allocate is true, and otherwise false is returned.
+*/
+func fieldByIndex(rv reflect.Value, index []int, allocate bool) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				if !allocate || !rv.CanSet() {
+					return reflect.Value{}, false
+				}
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, true
+}
+
+/*
+jsonPointerToken escapes name for use in a JSON Pointer.
+*/
+func jsonPointerToken(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
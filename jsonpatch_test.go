@@ -0,0 +1,146 @@
+package nullable
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type jsonPatchAddress struct {
+	City Nullable[string] `json:"city"`
+}
+
+type jsonPatchUser struct {
+	Name    Nullable[string]           `json:"name"`
+	Email   Nullable[string]           `json:"email"`
+	Age     Nullable[int]              `json:"age"`
+	Home    jsonPatchAddress           `json:"home"`
+	Work    *jsonPatchAddress          `json:"work"`
+	Billing Nullable[jsonPatchAddress] `json:"billing"`
+	Extra   RawNullable                `json:"a/b~c"`
+	Ignored int                        `json:"ignored"`
+}
+
+func TestGenerateJSONPatch(t *testing.T) {
+	var user jsonPatchUser
+	err := json.Unmarshal([]byte(`{
+		"name": "Ann",
+		"email": null,
+		"home": {"city": "Oslo"},
+		"work": {"city": null},
+		"billing": {"city": "Bergen"},
+		"a/b~c": [1, 2],
+		"ignored": 1
+	}`), &user)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() err = %v. Expected nil.", err)
+	}
+
+	{
+		ops, err := JSONPatch{}.Generate(&user)
+		if err != nil {
+			t.Fatalf("Generate(&user) = %v. Expected %v.", err, nil)
+		}
+		got, _ := json.Marshal(ops)
+		expected := `[{"op":"replace","path":"/name","value":"Ann"},{"op":"replace","path":"/email","value":null},{"op":"replace","path":"/home/city","value":"Oslo"},{"op":"replace","path":"/work/city","value":null},{"op":"replace","path":"/billing","value":{"city":"Bergen"}},{"op":"replace","path":"/a~1b~0c","value":[1,2]}]`
+		if string(got) != expected {
+			t.Errorf("Generate(&user) = %s. Expected %s.", got, expected)
+		}
+	}
+	{
+		ops, err := JSONPatch{RemoveNulls: true, Add: true}.Generate(&user)
+		if err != nil {
+			t.Fatalf("Generate(&user) = %v. Expected %v.", err, nil)
+		}
+		got, _ := json.Marshal(ops)
+		expected := `[{"op":"add","path":"/name","value":"Ann"},{"op":"remove","path":"/email"},{"op":"add","path":"/home/city","value":"Oslo"},{"op":"remove","path":"/work/city"},{"op":"add","path":"/billing","value":{"city":"Bergen"}},{"op":"add","path":"/a~1b~0c","value":[1,2]}]`
+		if string(got) != expected {
+			t.Errorf("Generate(&user) = %s. Expected %s.", got, expected)
+		}
+	}
+	if ops, err := GenerateJSONPatch(jsonPatchUser{}); err != nil || len(ops) != 0 {
+		t.Errorf("GenerateJSONPatch(empty) = %v, %v. Expected [], nil.", ops, err)
+	}
+	if _, err := GenerateJSONPatch(1); err == nil {
+		t.Errorf("GenerateJSONPatch(1) err = nil. Expected an error.")
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	user := jsonPatchUser{Name: From("Ann"), Email: From("ann@example.com"), Billing: From(jsonPatchAddress{})}
+	var patch []PatchOp
+	err := json.Unmarshal([]byte(`[
+		{"op": "test", "path": "/name", "value": "Ann"},
+		{"op": "replace", "path": "/name", "value": "Bob"},
+		{"op": "remove", "path": "/email"},
+		{"op": "add", "path": "/age", "value": 30},
+		{"op": "add", "path": "/work/city", "value": "Oslo"},
+		{"op": "add", "path": "/billing/city", "value": "Bergen"},
+		{"op": "add", "path": "/a~1b~0c", "value": {"x": 1}},
+		{"op": "replace", "path": "/age", "value": 31}
+	]`), &patch)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() err = %v. Expected nil.", err)
+	}
+
+	changed, err := ApplyJSONPatch(&user, patch)
+	if err != nil {
+		t.Fatalf("ApplyJSONPatch() err = %v. Expected nil.", err)
+	}
+	if expected := []string{"/age", "/work/city", "/billing/city", "/a~1b~0c"}; !reflect.DeepEqual(changed, expected) {
+		t.Errorf("ApplyJSONPatch() = %v. Expected %v.", changed, expected)
+	}
+	if user.Name.ValueOrDefault() != "Bob" || !user.Email.IsPresent() || !user.Email.IsNull() || user.Age.ValueOrDefault() != 31 {
+		t.Errorf("ApplyJSONPatch() user = %+v.", user)
+	}
+	if user.Work == nil || user.Work.City.ValueOrDefault() != "Oslo" || user.Billing.ValueOrDefault().City.ValueOrDefault() != "Bergen" {
+		t.Errorf("ApplyJSONPatch() nested fields = %+v, %+v.", user.Work, user.Billing)
+	}
+	if string(user.Extra.Raw()) != `{"x": 1}` {
+		t.Errorf("ApplyJSONPatch() Extra = %s. Expected %s.", user.Extra.Raw(), `{"x": 1}`)
+	}
+
+	failures := []PatchOp{
+		{Op: "test", Path: "/name", Value: json.RawMessage(`"Ann"`)},
+		{Op: "replace", Path: "/missing", Value: json.RawMessage(`1`)},
+		{Op: "replace", Path: "/ignored", Value: json.RawMessage(`1`)},
+		{Op: "replace", Path: "/age", Value: json.RawMessage(`"x"`)},
+		{Op: "replace", Path: "/age"},
+		{Op: "move", Path: "/age", From: "/name"},
+		{Op: "replace", Path: "age", Value: json.RawMessage(`1`)},
+		{Op: "remove", Path: "/home/city"},
+		{Op: "replace", Path: "/home/city", Value: json.RawMessage(`"Oslo"`)},
+	}
+	for _, op := range failures {
+		_, err := ApplyJSONPatch(&user, []PatchOp{op})
+		var patchErr *PatchError
+		if !errors.As(err, &patchErr) || patchErr.Index != 0 {
+			t.Errorf("ApplyJSONPatch(%+v) err = %v. Expected a *PatchError.", op, err)
+		}
+	}
+
+	var empty jsonPatchUser
+	if _, err := ApplyJSONPatch(&empty, []PatchOp{{Op: "replace", Path: "/work/city", Value: json.RawMessage(`"Oslo"`)}}); err == nil || empty.Work != nil {
+		t.Errorf("ApplyJSONPatch(replace /work/city) = %v, %+v. Expected an error and a nil Work.", err, empty.Work)
+	}
+}
+
+func TestJSONPatchRoundTrip(t *testing.T) {
+	source := jsonPatchUser{Name: From("Ann"), Email: Null[string](), Work: &jsonPatchAddress{City: From("Oslo")}}
+	for _, options := range []JSONPatch{{}, {RemoveNulls: true, Add: true}} {
+		ops, err := options.Generate(source)
+		if err != nil {
+			t.Fatalf("%+v.Generate() err = %v. Expected nil.", options, err)
+		}
+		// remove and replace require their target to exist.
+		got := jsonPatchUser{Name: From("Bob"), Email: From("ann@example.com"), Work: &jsonPatchAddress{City: From("Bergen")}}
+		if _, err := ApplyJSONPatch(&got, ops); err != nil {
+			t.Fatalf("ApplyJSONPatch() err = %v. Expected nil.", err)
+		}
+		if got.Name.ValueOrDefault() != "Ann" || !got.Email.IsPresent() || !got.Email.IsNull() || got.Age.IsPresent() ||
+			got.Work == nil || got.Work.City.ValueOrDefault() != "Oslo" {
+			t.Errorf("ApplyJSONPatch(%+v.Generate()) = %+v. Expected %+v.", options, got, source)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+/*
+Lenient holds the options used to leniently decode JSON.
+The zero value is ready to use.
+*/
+type Lenient struct {
+	// NullStrings are the strings decoded as null into Nullables that don't hold strings. The default is the empty string alone.
+	NullStrings []string
+}
+
+/*
+UnmarshalLenient decodes data into v using a zero Lenient.
+*/
+func UnmarshalLenient(data []byte, v any) error {
+	return Lenient{}.Unmarshal(data, v)
+}
+
+/*
+Unmarshal decodes data into v like json.Unmarshal, after coercing values that commonly arrive in the wrong form.
+
+  - Strings holding numbers are decoded into numeric types, and strings holding booleans into bool.
+  - Numbers with a fractional part or exponent, such as 12.0 or 1e3, are decoded into integer types if they are exactly whole, without rounding through float64.
+  - Strings listed in NullStrings are decoded as null into Nullables that don't hold strings.
+
+	type Listing struct {
+		Price nullable.Nullable[float64] `json:"price"`
+		Count nullable.Nullable[int]     `json:"count"`
+		Flag  nullable.Nullable[bool]    `json:"flag"`
+	}
+
+	var listing Listing
+	nullable.Lenient{NullStrings: []string{"", "N/A"}}.Unmarshal(
+		[]byte(`{"price": "12.50", "count": "N/A", "flag": "true"}`),
+		&listing,
+	)
+	listing.Price.Value()  // 12.5
+	listing.Count.IsNull() // true
+
+Coerced values are still range checked by encoding/json, so a value that overflows its type is reported as a *json.UnmarshalTypeError.
+Types that implement json.Unmarshaler themselves, other than Nullable, receive their JSON unchanged.
+*/
+func (l Lenient) Unmarshal(data []byte, v any) error {
+	var raw any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+
+	coerced, err := json.Marshal(l.coerce(raw, reflect.TypeOf(v)))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(coerced, v)
+}
+
+func (l Lenient) coerce(raw any, typ reflect.Type) any {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	isNullable := false
+	if n, ok := reflect.New(typ).Interface().(reflector); ok {
+		isNullable = true
+		typ = n.elemType()
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+	}
+	if raw == nil || reflect.PtrTo(typ).Implements(jsonUnmarshalerType) {
+		return raw
+	}
+
+	if s, ok := raw.(string); ok && isNullable && typ.Kind() != reflect.String && l.isNull(s) {
+		return nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if num, ok := toNumber(raw); ok {
+			return wholeNumber(num)
+		}
+	case reflect.Float32, reflect.Float64:
+		if num, ok := toNumber(raw); ok {
+			return num
+		}
+	case reflect.Bool:
+		if s, ok := raw.(string); ok {
+			if b, err := strconv.ParseBool(strings.TrimSpace(s)); err == nil {
+				return b
+			}
+		}
+	case reflect.Struct:
+		obj, ok := raw.(map[string]any)
+		if !ok {
+			return raw
+		}
+		coerced := make(map[string]any, len(obj))
+		for key, val := range obj {
+			coerced[key] = val
+		}
+		for _, field := range jsonFields(typ) {
+			if key, val, ok := lookupKey(obj, field.name); ok {
+				coerced[key] = l.coerce(val, field.typ)
+			}
+		}
+		return coerced
+	case reflect.Map:
+		obj, ok := raw.(map[string]any)
+		if !ok {
+			return raw
+		}
+		coerced := make(map[string]any, len(obj))
+		for key, val := range obj {
+			coerced[key] = l.coerce(val, typ.Elem())
+		}
+		return coerced
+	case reflect.Slice, reflect.Array:
+		arr, ok := raw.([]any)
+		if !ok {
+			return raw
+		}
+		coerced := make([]any, len(arr))
+		for i, val := range arr {
+			coerced[i] = l.coerce(val, typ.Elem())
+		}
+		return coerced
+	}
+	return raw
+}
+
+func (l Lenient) isNull(s string) bool {
+	if l.NullStrings == nil {
+		return s == ""
+	}
+	for _, null := range l.NullStrings {
+		if s == null {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+toNumber returns raw as a json.Number if it is one, or if it is a string holding one.
+*/
+func toNumber(raw any) (json.Number, bool) {
+	switch raw := raw.(type) {
+	case json.Number:
+		return raw, true
+	case string:
+		s := strings.TrimSpace(raw)
+		if _, err := strconv.ParseFloat(s, 64); err != nil || !json.Valid([]byte(s)) {
+			return "", false
+		}
+		return json.Number(s), true
+	}
+	return "", false
+}
+
+/*
+wholeNumber rewrites num without a fractional part or exponent if it is exactly a whole number.
+The digits are shifted as text rather than through float64, so no precision is lost.
+Anything else, including whole numbers too long to fit in 64 bits, is returned unchanged so that encoding/json reports it.
+*/
+func wholeNumber(num json.Number) json.Number {
+	text := string(num)
+	if !strings.ContainsAny(text, ".eE") {
+		return num
+	}
+
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign, text = "-", text[1:]
+	}
+	mantissa, exponent := text, 0
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(text[i+1:])
+		if err != nil {
+			return num
+		}
+		mantissa, exponent = text[:i], exp
+	}
+	whole, frac, _ := strings.Cut(mantissa, ".")
+
+	// num is digits * 10^shift.
+	digits := strings.TrimLeft(whole+frac, "0")
+	shift := exponent - len(frac)
+	switch {
+	case digits == "":
+		return "0"
+	case shift < 0:
+		if -shift > len(digits) || strings.TrimRight(digits[len(digits)+shift:], "0") != "" {
+			return num
+		}
+		digits = digits[:len(digits)+shift]
+	case len(digits)+shift > len("18446744073709551615"):
+		return num
+	default:
+		digits += strings.Repeat("0", shift)
+	}
+	return json.Number(sign + digits)
+}
@@ -0,0 +1,120 @@
+package nullable
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUnmarshalLenient(t *testing.T) {
+	type S struct {
+		Price Nullable[float64]   `json:"price"`
+		Count Nullable[int]       `json:"count"`
+		Flag  Nullable[bool]      `json:"flag"`
+		Name  Nullable[string]    `json:"name"`
+		Small Nullable[int8]      `json:"small"`
+		Whole Nullable[uint32]    `json:"whole"`
+		IDs   Nullable[[]int64]   `json:"ids"`
+		Plain float32             `json:"plain"`
+		When  Nullable[time.Time] `json:"when"`
+	}
+	{
+		var s S
+		err := UnmarshalLenient([]byte(`{
+			"price": "12.50",
+			"count": "",
+			"flag": "true",
+			"name": "",
+			"whole": 1e3,
+			"ids": ["1", 2.0],
+			"plain": " 1.5 ",
+			"when": "2022-06-01T00:00:00Z"
+		}`), &s)
+		if err != nil {
+			t.Fatalf("UnmarshalLenient(j, &s) = %v. Expected %v.", err, nil)
+		}
+		if s.Price.ptr == nil || *s.Price.ptr != 12.5 {
+			t.Errorf("s.Price = %+v. Expected %v.", s.Price, 12.5)
+		}
+		if s.Count.ptr != nil || s.Count.present == false {
+			t.Errorf("s.Count = %+v. Expected present null.", s.Count)
+		}
+		if s.Flag.ptr == nil || *s.Flag.ptr != true {
+			t.Errorf("s.Flag = %+v. Expected %v.", s.Flag, true)
+		}
+		if s.Name.ptr == nil || *s.Name.ptr != "" {
+			t.Errorf("s.Name = %+v. Expected %q.", s.Name, "")
+		}
+		if s.Small.present == true {
+			t.Error("s.Small.IsPresent() = true. Expected false.")
+		}
+		if s.Whole.ptr == nil || *s.Whole.ptr != 1000 {
+			t.Errorf("s.Whole = %+v. Expected %v.", s.Whole, 1000)
+		}
+		if s.IDs.ptr == nil || len(*s.IDs.ptr) != 2 || (*s.IDs.ptr)[0] != 1 || (*s.IDs.ptr)[1] != 2 {
+			t.Errorf("s.IDs = %+v. Expected %v.", s.IDs, []int64{1, 2})
+		}
+		if s.Plain != 1.5 {
+			t.Errorf("s.Plain = %v. Expected %v.", s.Plain, 1.5)
+		}
+		if s.When.ptr == nil || s.When.ptr.Year() != 2022 {
+			t.Errorf("s.When = %+v. Expected %v.", s.When, "2022-06-01")
+		}
+	}
+	{
+		var s S
+		err := Lenient{NullStrings: []string{"", "null", "N/A"}}.Unmarshal([]byte(`{"price": "N/A", "flag": "null", "name": "N/A"}`), &s)
+		if err != nil {
+			t.Fatalf("Unmarshal(j, &s) = %v. Expected %v.", err, nil)
+		}
+		if s.Price.ptr != nil || s.Price.present == false {
+			t.Errorf("s.Price = %+v. Expected present null.", s.Price)
+		}
+		if s.Flag.ptr != nil || s.Flag.present == false {
+			t.Errorf("s.Flag = %+v. Expected present null.", s.Flag)
+		}
+		if s.Name.ptr == nil || *s.Name.ptr != "N/A" {
+			t.Errorf("s.Name = %+v. Expected %v.", s.Name, "N/A")
+		}
+	}
+	{
+		var s S
+		err := UnmarshalLenient([]byte(`{"small": "300"}`), &s)
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) {
+			t.Errorf("UnmarshalLenient(j, &s) = %v. Expected *json.UnmarshalTypeError.", err)
+		}
+	}
+	{
+		var s S
+		err := UnmarshalLenient([]byte(`{"count": 1.5}`), &s)
+		if err == nil {
+			t.Errorf("UnmarshalLenient(j, &s) = %v. Expected error.", err)
+		}
+	}
+	{
+		var n Nullable[[]int64]
+		err := UnmarshalLenient([]byte(`["12345678901234567.0", 1.25e2, -4.00E+1, 0.0e-3, 9.223372036854775807e18]`), &n)
+		expected := []int64{12345678901234567, 125, -40, 0, 9223372036854775807}
+		if err != nil || !reflect.DeepEqual(n.ValueOrDefault(), expected) {
+			t.Errorf("UnmarshalLenient(j, &n) = %v, %v. Expected %v, %v.", n.ValueOrDefault(), err, expected, nil)
+		}
+	}
+	{
+		for _, j := range []string{`[1.0000000000000001]`, `[1e-1]`, `[9.3e18]`, `[1e400]`, `[1e99999999999999999999]`} {
+			var n Nullable[[]int64]
+			if err := UnmarshalLenient([]byte(j), &n); err == nil {
+				t.Errorf("UnmarshalLenient(%s, &n) = %v. Expected error.", j, n.ValueOrDefault())
+			}
+		}
+	}
+	{
+		var s S
+		err := json.Unmarshal([]byte(`{"price": "12.50"}`), &s)
+		if err == nil {
+			t.Errorf("json.Unmarshal(j, &s) = %v. Expected error.", err)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+/*
+ListPatch describes a change to a slice of T whose elements are identified by keys of type K.
+Unlike a Nullable[[]T], which can only replace the whole slice, a ListPatch can also add, remove and update individual elements.
+Its JSON is either a replacement, written as an array or null, or an object of operations:
+
+	{"add": [{"id": 3, "name": "new"}], "remove": [1], "update": [{"id": 2, "name": "renamed"}]}
+
+Like a Nullable, an absent ListPatch leaves the slice unchanged.
+
+	type UpdateOrder struct {
+		Items nullable.ListPatch[Item, int] `json:"items"`
+	}
+
+	items, err := request.Items.Apply(order.Items, func(item Item) int { return item.ID })
+*/
+type ListPatch[T any, K comparable] struct {
+	// Replace replaces the whole slice when present. A null Replace makes the slice nil.
+	Replace Nullable[[]T]
+	// Add holds elements appended to the slice.
+	Add []T
+	// Remove holds the keys of elements removed from the slice.
+	Remove []K
+	// Update holds partial elements merged into the elements with the same key, as if by Merge.
+	Update []T
+}
+
+/*
+listOps is the JSON form of the operations of a ListPatch.
+*/
+type listOps[T any, K comparable] struct {
+	Add    []T `json:"add,omitempty"`
+	Remove []K `json:"remove,omitempty"`
+	Update []T `json:"update,omitempty"`
+}
+
+/*
+IsPresent returns true if the ListPatch replaces the slice or holds any operations.
+*/
+func (p ListPatch[T, K]) IsPresent() bool {
+	return p.Replace.IsPresent() || p.Add != nil || p.Remove != nil || p.Update != nil
+}
+
+/*
+Apply returns the result of applying the ListPatch to dst, using key to identify elements.
+The replacement is applied first, followed by removals, updates and additions, in that order.
+Updates are merged into the existing element like Merge does, so only the Nullable fields that are present change and T should be a struct.
+Removing a missing key has no effect, while updating a missing key or adding a key that already exists is an error.
+The elements of dst are copied, so its backing array is never modified.
+*/
+func (p ListPatch[T, K]) Apply(dst []T, key func(T) K) ([]T, error) {
+	if !p.IsPresent() {
+		return dst, nil
+	}
+	if p.Replace.IsPresent() {
+		dst = p.Replace.ValueOrDefault()
+	}
+	if p.Add == nil && p.Remove == nil && p.Update == nil {
+		return dst, nil
+	}
+
+	removed := make(map[K]bool, len(p.Remove))
+	for _, k := range p.Remove {
+		removed[k] = true
+	}
+	result := make([]T, 0, len(dst)+len(p.Add))
+	index := make(map[K]int, len(dst)+len(p.Add))
+	for _, elem := range dst {
+		k := key(elem)
+		if removed[k] {
+			continue
+		}
+		index[k] = len(result)
+		result = append(result, elem)
+	}
+
+	for _, update := range p.Update {
+		k := key(update)
+		i, ok := index[k]
+		if !ok {
+			return dst, fmt.Errorf("ListPatch.Apply() called with an update for missing key %v", k)
+		}
+		if _, err := Merge(&result[i], update); err != nil {
+			return dst, err
+		}
+	}
+
+	for _, elem := range p.Add {
+		k := key(elem)
+		if _, ok := index[k]; ok {
+			return dst, fmt.Errorf("ListPatch.Apply() called with an addition for existing key %v", k)
+		}
+		index[k] = len(result)
+		result = append(result, elem)
+	}
+	return result, nil
+}
+
+/*
+UnmarshalJSON implements the json.Unmarshaler interface.
+Arrays and null replace the slice, and objects are decoded as operations.
+Unknown operations are reported as errors so that typos don't silently do nothing.
+*/
+func (p *ListPatch[T, K]) UnmarshalJSON(raw []byte) error {
+	*p = ListPatch[T, K]{}
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return p.Replace.UnmarshalJSON(raw)
+	}
+
+	var ops listOps[T, K]
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&ops); err != nil {
+		return err
+	}
+	p.Add, p.Remove, p.Update = ops.Add, ops.Remove, ops.Update
+	if p.Add == nil && p.Remove == nil && p.Update == nil {
+		p.Add = []T{}
+	}
+	return nil
+}
+
+/*
+MarshalJSON implements the json.Marshaler interface.
+A present replacement is written as an array or null, and operations are written as an object.
+Like a Nullable, an absent ListPatch is written as null.
+*/
+func (p ListPatch[T, K]) MarshalJSON() ([]byte, error) {
+	if p.Replace.IsPresent() || !p.IsPresent() {
+		return p.Replace.MarshalJSON()
+	}
+	return json.Marshal(listOps[T, K]{Add: p.Add, Remove: p.Remove, Update: p.Update})
+}
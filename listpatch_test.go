@@ -0,0 +1,154 @@
+package nullable
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type listPatchItem struct {
+	ID    int              `json:"id"`
+	Name  Nullable[string] `json:"name"`
+	Price Nullable[int]    `json:"price"`
+}
+
+func listPatchKey(item listPatchItem) int {
+	return item.ID
+}
+
+func TestListPatchUnmarshalJSON(t *testing.T) {
+	type Order struct {
+		Items ListPatch[listPatchItem, int] `json:"items"`
+	}
+	{
+		var order Order
+		if err := json.Unmarshal([]byte(`{}`), &order); err != nil {
+			t.Fatalf("json.Unmarshal(j, &order) = %v. Expected %v.", err, nil)
+		}
+		if order.Items.IsPresent() != false || order.Items.Replace.IsPresent() != false {
+			t.Errorf("order.Items = %+v. Expected IsPresent() = false and Replace.IsPresent() = false.", order.Items)
+		}
+	}
+	{
+		var order Order
+		if err := json.Unmarshal([]byte(`{"items": null}`), &order); err != nil {
+			t.Fatalf("json.Unmarshal(j, &order) = %v. Expected %v.", err, nil)
+		}
+		if order.Items.IsPresent() != true || order.Items.Replace.IsPresent() != true {
+			t.Errorf("order.Items = %+v. Expected IsPresent() = true and Replace.IsPresent() = true.", order.Items)
+		}
+	}
+	{
+		var order Order
+		if err := json.Unmarshal([]byte(`{"items": [{"id": 1}]}`), &order); err != nil {
+			t.Fatalf("json.Unmarshal(j, &order) = %v. Expected %v.", err, nil)
+		}
+		if order.Items.IsPresent() != true || order.Items.Replace.IsPresent() != true {
+			t.Errorf("order.Items = %+v. Expected IsPresent() = true and Replace.IsPresent() = true.", order.Items)
+		}
+	}
+	{
+		var order Order
+		if err := json.Unmarshal([]byte(`{"items": {}}`), &order); err != nil {
+			t.Fatalf("json.Unmarshal(j, &order) = %v. Expected %v.", err, nil)
+		}
+		if order.Items.IsPresent() != true || order.Items.Replace.IsPresent() != false {
+			t.Errorf("order.Items = %+v. Expected IsPresent() = true and Replace.IsPresent() = false.", order.Items)
+		}
+	}
+	{
+		var order Order
+		if err := json.Unmarshal([]byte(`{"items": {"add": [{"id": 1}], "remove": [2]}}`), &order); err != nil {
+			t.Fatalf("json.Unmarshal(j, &order) = %v. Expected %v.", err, nil)
+		}
+		if !order.Items.IsPresent() || order.Items.Replace.IsPresent() || len(order.Items.Add) != 1 || !reflect.DeepEqual(order.Items.Remove, []int{2}) {
+			t.Errorf("order.Items = %+v. Expected one addition and one removal.", order.Items)
+		}
+	}
+	{
+		var order Order
+		if err := json.Unmarshal([]byte(`{"items": {"append": [{"id": 1}]}}`), &order); err == nil {
+			t.Errorf("json.Unmarshal(j, &order) = %v. Expected error.", err)
+		}
+	}
+	{
+		var order Order
+		if err := json.Unmarshal([]byte(`{"items": "x"}`), &order); err == nil {
+			t.Errorf("json.Unmarshal(j, &order) = %v. Expected error.", err)
+		}
+	}
+}
+
+func TestListPatchApply(t *testing.T) {
+	items := []listPatchItem{
+		{ID: 1, Name: From("one"), Price: From(10)},
+		{ID: 2, Name: From("two"), Price: From(20)},
+		{ID: 3, Name: From("three"), Price: From(30)},
+	}
+
+	var patch ListPatch[listPatchItem, int]
+	err := json.Unmarshal([]byte(`{
+		"add": [{"id": 4, "name": "four"}],
+		"remove": [1, 9],
+		"update": [{"id": 2, "price": null}, {"id": 3, "name": "THREE"}]
+	}`), &patch)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() err = %v. Expected nil.", err)
+	}
+
+	got, err := patch.Apply(items, listPatchKey)
+	if err != nil {
+		t.Fatalf("patch.Apply() err = %v. Expected nil.", err)
+	}
+	expected := []listPatchItem{
+		{ID: 2, Name: From("two"), Price: Null[int]()},
+		{ID: 3, Name: From("THREE"), Price: From(30)},
+		{ID: 4, Name: From("four")},
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("patch.Apply() = %v. Expected %v.", got, expected)
+	}
+	for i := range got {
+		if got[i].ID != expected[i].ID || got[i].Name.ValueOrDefault() != expected[i].Name.ValueOrDefault() ||
+			got[i].Price.IsNull() != expected[i].Price.IsNull() || got[i].Price.ValueOrDefault() != expected[i].Price.ValueOrDefault() {
+			t.Errorf("patch.Apply()[%d] = %+v. Expected %+v.", i, got[i], expected[i])
+		}
+	}
+	if items[1].Price.IsNull() || items[2].Name.ValueOrDefault() != "three" {
+		t.Errorf("patch.Apply() modified dst: %+v.", items)
+	}
+
+	if got, err := (ListPatch[listPatchItem, int]{}).Apply(items, listPatchKey); err != nil || !reflect.DeepEqual(got, items) {
+		t.Errorf("absent.Apply() = %v, %v. Expected dst, nil.", got, err)
+	}
+	if got, err := (ListPatch[listPatchItem, int]{Replace: Null[[]listPatchItem]()}).Apply(items, listPatchKey); err != nil || got != nil {
+		t.Errorf("null.Apply() = %v, %v. Expected nil, nil.", got, err)
+	}
+	if _, err := (ListPatch[listPatchItem, int]{Update: []listPatchItem{{ID: 9}}}).Apply(items, listPatchKey); err == nil {
+		t.Errorf("patch.Apply() with an update for a missing key err = nil. Expected an error.")
+	}
+	if _, err := (ListPatch[listPatchItem, int]{Add: []listPatchItem{{ID: 1}}}).Apply(items, listPatchKey); err == nil {
+		t.Errorf("patch.Apply() with an addition for an existing key err = nil. Expected an error.")
+	}
+}
+
+func TestListPatchMarshalJSON(t *testing.T) {
+	{
+		got, err := json.Marshal(ListPatch[listPatchItem, int]{})
+		if err != nil || string(got) != `null` {
+			t.Errorf("json.Marshal(patch) = %s, %v. Expected %s, %v.", got, err, `null`, nil)
+		}
+	}
+	{
+		got, err := json.Marshal(ListPatch[listPatchItem, int]{Replace: From([]listPatchItem{{ID: 1, Name: From("a"), Price: From(1)}})})
+		if err != nil || string(got) != `[{"id":1,"name":"a","price":1}]` {
+			t.Errorf("json.Marshal(patch) = %s, %v. Expected %s, %v.", got, err, `[{"id":1,"name":"a","price":1}]`, nil)
+		}
+	}
+	{
+		got, err := json.Marshal(ListPatch[listPatchItem, int]{Remove: []int{1, 2}})
+		if err != nil || string(got) != `{"remove":[1,2]}` {
+			t.Errorf("json.Marshal(patch) = %s, %v. Expected %s, %v.", got, err, `{"remove":[1,2]}`, nil)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+Map is an ordered map whose values can be null.
+It is useful for payloads without a fixed schema, such as bags of custom attributes, where decoding into a map[string]any loses the difference between a missing key and a null value.
+Get returns an absent Nullable for missing keys and a null one for keys set to null.
+Keys are kept in insertion order, which is also the order they are marshaled in.
+The zero value is an empty map ready to use.
+Like a Go map, a Map that holds keys refers to shared storage, so changes made through a copy are seen by the original.
+
+	var attributes nullable.Map[string, string]
+	json.Unmarshal([]byte(`{"color": "red", "size": null}`), &attributes)
+	attributes.Get("color").Value()     // red
+	attributes.Get("size").IsNull()     // true
+	attributes.Get("weight").IsAbsent() // true
+*/
+type Map[K ~string, V any] struct {
+	entries *mapEntries[K, V]
+}
+
+/*
+mapEntries holds the contents of a Map behind a pointer, so that copies of a Map keep its keys and values in step.
+*/
+type mapEntries[K ~string, V any] struct {
+	keys   []K
+	values map[K]*V
+}
+
+/*
+Record is a Map of arbitrary JSON values. Use GetAs to read its values as a specific type.
+*/
+type Record = Map[string, any]
+
+/*
+Len returns the number of keys in the Map.
+*/
+func (m Map[K, V]) Len() int {
+	return len(m.keyList())
+}
+
+/*
+Keys returns the keys of the Map in insertion order.
+*/
+func (m Map[K, V]) Keys() []K {
+	return append([]K(nil), m.keyList()...)
+}
+
+func (m Map[K, V]) keyList() []K {
+	if m.entries == nil {
+		return nil
+	}
+	return m.entries.keys
+}
+
+func (m Map[K, V]) lookup(key K) (*V, bool) {
+	if m.entries == nil {
+		return nil, false
+	}
+	ptr, ok := m.entries.values[key]
+	return ptr, ok
+}
+
+/*
+Get returns the value of key.
+The Nullable is absent if the key isn't in the Map, and null if it was set to null.
+*/
+func (m Map[K, V]) Get(key K) Nullable[V] {
+	ptr, ok := m.lookup(key)
+	if !ok {
+		return Absent[V]()
+	}
+	if ptr == nil {
+		return Null[V]()
+	}
+	return From(*ptr)
+}
+
+/*
+Range calls f for each key and its value in insertion order, stopping if f returns false.
+*/
+func (m Map[K, V]) Range(f func(key K, value Nullable[V]) bool) {
+	for _, key := range m.keyList() {
+		if !f(key, m.Get(key)) {
+			return
+		}
+	}
+}
+
+/*
+Set sets the value of key. A new key is added after the existing ones.
+*/
+func (m *Map[K, V]) Set(key K, value V) {
+	m.put(key, &value)
+}
+
+/*
+SetNull sets key to null. A new key is added after the existing ones.
+*/
+func (m *Map[K, V]) SetNull(key K) {
+	m.put(key, nil)
+}
+
+/*
+Delete removes key from the Map, making it absent.
+*/
+func (m *Map[K, V]) Delete(key K) {
+	if _, ok := m.lookup(key); !ok {
+		return
+	}
+	e := m.entries
+	delete(e.values, key)
+	for i, k := range e.keys {
+		if k == key {
+			e.keys = append(e.keys[:i], e.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+func (m *Map[K, V]) put(key K, ptr *V) {
+	if m.entries == nil {
+		m.entries = &mapEntries[K, V]{values: map[K]*V{}}
+	}
+	e := m.entries
+	if _, ok := e.values[key]; !ok {
+		e.keys = append(e.keys, key)
+	}
+	e.values[key] = ptr
+}
+
+/*
+UnmarshalJSON implements the json.Unmarshaler interface.
+Like encoding/json does for maps, the keys of the object are added to the existing ones and the JSON null literal leaves the Map unchanged.
+When a key appears more than once, the last value wins but the key keeps its first position.
+If a value can't be decoded, an *UnmarshalError with a path starting at the key is returned.
+*/
+func (m *Map[K, V]) UnmarshalJSON(raw []byte) error {
+	if isJSONNull(raw) {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('{') {
+		return &json.UnmarshalTypeError{
+			Value:  jsonTokenKind(tok),
+			Type:   reflect.TypeOf(m).Elem(),
+			Offset: dec.InputOffset(),
+		}
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		var n Nullable[V]
+		if err := n.UnmarshalJSON(value); err != nil {
+			return unmarshalErrorAt(key, err)
+		}
+		m.put(K(key), n.ptr)
+	}
+	_, err = dec.Token()
+	return err
+}
+
+/*
+MarshalJSON implements the json.Marshaler interface by writing the keys in insertion order.
+*/
+func (m Map[K, V]) MarshalJSON() ([]byte, error) {
+	buf := append(make([]byte, 0, 64), '{')
+	for i, key := range m.keyList() {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		if utf8.ValidString(string(key)) {
+			buf = appendJSONString(buf, string(key))
+		} else {
+			quoted, err := json.Marshal(string(key))
+			if err != nil {
+				return nil, err
+			}
+			buf = append(buf, quoted...)
+		}
+		buf = append(buf, ':')
+
+		var err error
+		ptr, _ := m.lookup(key)
+		buf, err = Nullable[V]{ptr: ptr, present: true}.AppendJSON(buf)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return append(buf, '}'), nil
+}
+
+/*
+GetAs returns the value of key converted to T.
+Values that already have type T are returned as is, and any other value is converted by encoding it as JSON and decoding it into a Nullable[T].
+As with Get, the Nullable is absent if the key isn't in the Map and null if it was set to null.
+If the value can't be converted, an *UnmarshalError is returned.
+
+	var record nullable.Record
+	json.Unmarshal([]byte(`{"age": 42, "tags": ["a", "b"]}`), &record)
+	age, err := nullable.GetAs[int](record, "age")
+	tags, err := nullable.GetAs[[]string](record, "tags")
+*/
+func GetAs[T any, K ~string, V any](m Map[K, V], key K) (Nullable[T], error) {
+	ptr, ok := m.lookup(key)
+	if !ok {
+		return Absent[T](), nil
+	}
+	if ptr == nil {
+		return Null[T](), nil
+	}
+	if value, ok := any(*ptr).(T); ok {
+		return From(value), nil
+	}
+
+	raw, ok := any(*ptr).(json.RawMessage)
+	if !ok {
+		var err error
+		if raw, err = json.Marshal(*ptr); err != nil {
+			return Nullable[T]{}, err
+		}
+	}
+	var n Nullable[T]
+	if err := n.UnmarshalJSON(raw); err != nil {
+		return Nullable[T]{}, unmarshalErrorAt(string(key), err)
+	}
+	return n, nil
+}
+
+/*
+unmarshalErrorAt prefixes the path of an *UnmarshalError with key. Other errors are returned unchanged.
+*/
+func unmarshalErrorAt(key string, err error) error {
+	var unmarshalErr *UnmarshalError
+	if !errors.As(err, &unmarshalErr) {
+		return err
+	}
+	moved := *unmarshalErr
+	moved.Path = "$" + jsonPathKey(key) + strings.TrimPrefix(moved.Path, "$")
+	return &moved
+}
+
+/*
+jsonTokenKind describes the JSON value that starts with tok, as used by json.UnmarshalTypeError.
+*/
+func jsonTokenKind(tok json.Token) string {
+	switch tok.(type) {
+	case json.Delim:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	}
+	return "number"
+}
@@ -0,0 +1,122 @@
+package nullable
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMapUnmarshalJSON(t *testing.T) {
+	var m Map[string, string]
+	err := json.Unmarshal([]byte(`{"color": "red", "size": null, "shape": "square", "color": "blue"}`), &m)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() err = %v. Expected nil.", err)
+	}
+	if got, expected := m.Keys(), []string{"color", "size", "shape"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("m.Keys() = %v. Expected %v.", got, expected)
+	}
+	if got := m.Get("color"); got.ValueOrDefault() != "blue" {
+		t.Errorf("m.Get(color) = %v. Expected blue.", got.ValueOrDefault())
+	}
+	if got := m.Get("size"); !got.IsPresent() || !got.IsNull() {
+		t.Errorf("m.Get(size) = %v. Expected a present null.", got)
+	}
+	if got := m.Get("weight"); got.IsPresent() {
+		t.Errorf("m.Get(weight) = %v. Expected absent.", got)
+	}
+
+	if err := json.Unmarshal([]byte(`null`), &m); err != nil || m.Len() != 3 {
+		t.Errorf("json.Unmarshal(null) = %v, Len() = %d. Expected nil, 3.", err, m.Len())
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if err := json.Unmarshal([]byte(`[1]`), &m); !errors.As(err, &typeErr) {
+		t.Errorf("json.Unmarshal([1]) err = %v. Expected a *json.UnmarshalTypeError.", err)
+	}
+
+	var numbers Map[string, []int]
+	err = json.Unmarshal([]byte(`{"a": [1], "b c": [2, "x"]}`), &numbers)
+	var unmarshalErr *UnmarshalError
+	if !errors.As(err, &unmarshalErr) || unmarshalErr.Path != `$["b c"][1]` {
+		t.Errorf("json.Unmarshal() err = %v. Expected an *UnmarshalError at $[\"b c\"][1].", err)
+	}
+}
+
+func TestMapMarshalJSON(t *testing.T) {
+	var m Map[string, int]
+	if got, err := json.Marshal(m); err != nil || string(got) != `{}` {
+		t.Errorf("json.Marshal(empty) = %s, %v. Expected {}, nil.", got, err)
+	}
+
+	m.Set("z", 1)
+	m.SetNull("a")
+	m.Set("<m>", 2)
+	m.Set("z", 3)
+	m.Set("deleted", 4)
+	m.Delete("deleted")
+	m.Delete("missing")
+	if got, err := json.Marshal(m); err != nil || string(got) != `{"z":3,"a":null,"\u003cm\u003e":2}` {
+		t.Errorf("json.Marshal(m) = %s, %v. Expected %s, nil.", got, err, `{"z":3,"a":null,"\u003cm\u003e":2}`)
+	}
+
+	var keys []string
+	m.Range(func(key string, value Nullable[int]) bool {
+		keys = append(keys, key)
+		return key != "a"
+	})
+	if expected := []string{"z", "a"}; !reflect.DeepEqual(keys, expected) {
+		t.Errorf("m.Range() visited %v. Expected %v.", keys, expected)
+	}
+}
+
+func TestMapCopy(t *testing.T) {
+	var a Map[string, int]
+	a.Set("x", 1)
+	b := a
+	b.Set("y", 2)
+	a.Set("y", 3)
+	b.Delete("x")
+	if got, err := json.Marshal(a); err != nil || string(got) != `{"y":3}` || a.Len() != 1 {
+		t.Errorf("json.Marshal(a) = %s, %v. Expected %s, nil.", got, err, `{"y":3}`)
+	}
+	if expected := []string{"y"}; !reflect.DeepEqual(b.Keys(), expected) || b.Get("y").ValueOrDefault() != 3 {
+		t.Errorf("b.Keys() = %v. Expected %v.", b.Keys(), expected)
+	}
+}
+
+func TestGetAs(t *testing.T) {
+	var record Record
+	err := json.Unmarshal([]byte(`{"age": 42, "name": "Ann", "tags": ["a", "b"], "ratio": 1.5, "gone": null}`), &record)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() err = %v. Expected nil.", err)
+	}
+
+	if age, err := GetAs[int](record, "age"); err != nil || age.ValueOrDefault() != 42 {
+		t.Errorf("GetAs[int](age) = %v, %v. Expected 42, nil.", age, err)
+	}
+	if name, err := GetAs[string](record, "name"); err != nil || name.ValueOrDefault() != "Ann" {
+		t.Errorf("GetAs[string](name) = %v, %v. Expected Ann, nil.", name, err)
+	}
+	if tags, err := GetAs[[]string](record, "tags"); err != nil || !reflect.DeepEqual(tags.ValueOrDefault(), []string{"a", "b"}) {
+		t.Errorf("GetAs[[]string](tags) = %v, %v. Expected [a b], nil.", tags, err)
+	}
+	if gone, err := GetAs[int](record, "gone"); err != nil || !gone.IsPresent() || !gone.IsNull() {
+		t.Errorf("GetAs[int](gone) = %v, %v. Expected a present null, nil.", gone, err)
+	}
+	if missing, err := GetAs[int](record, "missing"); err != nil || missing.IsPresent() {
+		t.Errorf("GetAs[int](missing) = %v, %v. Expected absent, nil.", missing, err)
+	}
+
+	_, err = GetAs[int](record, "ratio")
+	var unmarshalErr *UnmarshalError
+	if !errors.As(err, &unmarshalErr) || unmarshalErr.Path != "$.ratio" {
+		t.Errorf("GetAs[int](ratio) err = %v. Expected an *UnmarshalError at $.ratio.", err)
+	}
+
+	var raw Map[string, json.RawMessage]
+	raw.Set("count", json.RawMessage("7"))
+	if count, err := GetAs[int](raw, "count"); err != nil || count.ValueOrDefault() != 7 {
+		t.Errorf("GetAs[int](count) = %v, %v. Expected 7, nil.", count, err)
+	}
+}
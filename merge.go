@@ -0,0 +1,145 @@
+package nullable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+/*
+Provenance records which layer passed to Merge each merged field came from.
+Keys are field paths such as "Server.Port" and values are indexes into the layers.
+Fields that no layer marked as present are not recorded.
+*/
+type Provenance map[string]int
+
+/*
+Paths returns the paths recorded in the Provenance in sorted order.
+This is useful for printing the final configuration in a stable order.
+
+	provenance, _ := nullable.Merge(&config, fileConfig, envConfig, flagConfig)
+	names := []string{"file", "env", "flags"}
+	for _, path := range provenance.Paths() {
+		fmt.Printf("%s (from %s)\n", path, names[provenance[path]])
+	}
+
+*/
+func (p Provenance) Paths() []string {
+	paths := make([]string, 0, len(p))
+	for path := range p {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+/*
+presenter is implemented by every Nullable and is used to find Nullable fields when walking structs.
+*/
+type presenter interface {
+	interfaceable
+	IsPresent() bool
+}
+
+/*
+detacher is implemented by a pointer to every Nullable.
+detach replaces the held value with a copy, so that a Nullable copied from another no longer shares its value.
+*/
+type detacher interface {
+	detach()
+}
+
+/*
+detach implements detacher for the Nullable type.
+*/
+func (n *Nullable[T]) detach() {
+	if n.ptr != nil {
+		tmp := *n.ptr
+		n.ptr = &tmp
+	}
+}
+
+/*
+detach implements detacher for the RawNullable type.
+*/
+func (r *RawNullable) detach() {
+	if r.raw != nil {
+		r.raw = append(json.RawMessage{}, r.raw...)
+	}
+}
+
+/*
+Merge merges each layer into the struct pointed to by dst, in order.
+Layers must be structs, or pointers to structs, of the same type as dst, and nil layers are skipped.
+Every Nullable field that is present in a layer replaces the corresponding field in dst, so the last layer in which a field is present wins.
+An explicit null in a later layer overrides a value from an earlier one, while an absent field leaves dst unchanged.
+Nested structs and pointers to structs are merged recursively, and fields that aren't Nullable are ignored.
+Pointers to Nullables are followed like pointers to structs, so a nil pointer in a layer leaves dst unchanged.
+Values are copied into dst rather than shared with the layer they came from.
+
+The returned Provenance records the layer each replaced field came from.
+*/
+func Merge(dst any, layers ...any) (Provenance, error) {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return nil, errors.New("Merge() called with a non-pointer or nil destination")
+	}
+	rv = rv.Elem()
+
+	provenance := Provenance{}
+	for i, layer := range layers {
+		lv := reflect.ValueOf(layer)
+		if !lv.IsValid() {
+			continue
+		}
+		if lv.Kind() == reflect.Pointer {
+			if lv.IsNil() {
+				continue
+			}
+			lv = lv.Elem()
+		}
+		if lv.Type() != rv.Type() {
+			return nil, fmt.Errorf("Merge() called with layer %d of type %v. Expected %v", i, lv.Type(), rv.Type())
+		}
+		merge(rv, lv, "", i, provenance)
+	}
+	return provenance, nil
+}
+
+func merge(dst, src reflect.Value, path string, layer int, provenance Provenance) {
+	switch dst.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() || dst.Type().Elem().Kind() != reflect.Struct {
+			return
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		merge(dst.Elem(), src.Elem(), path, layer, provenance)
+	case reflect.Struct:
+		if p, ok := src.Interface().(presenter); ok {
+			if p.IsPresent() {
+				dst.Set(src)
+				dst.Addr().Interface().(detacher).detach()
+				provenance[path] = layer
+			}
+			return
+		}
+
+		rt := dst.Type()
+		for i := 0; i < rt.NumField(); i++ {
+			field := rt.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fieldPath := field.Name
+			if path != "" {
+				fieldPath = path + "." + field.Name
+			}
+
+			merge(dst.Field(i), src.Field(i), fieldPath, layer, provenance)
+		}
+	}
+}
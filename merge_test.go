@@ -0,0 +1,114 @@
+package nullable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	type Server struct {
+		Host Nullable[string]
+		Port Nullable[int]
+	}
+	type Config struct {
+		Server  Server
+		Admin   *Server
+		Debug   Nullable[bool]
+		Name    Nullable[string]
+		Limit   *Nullable[int]
+		Ignored int
+	}
+	{
+		file := Config{
+			Server: Server{Host: From("localhost"), Port: From(80)},
+			Debug:  From(true),
+			Name:   From("file"),
+		}
+		env := Config{
+			Server: Server{Port: From(8080)},
+			Name:   Null[string](),
+		}
+		limit := From(5)
+		flags := &Config{
+			Admin:   &Server{Port: From(9090)},
+			Limit:   &limit,
+			Debug:   From(false),
+			Ignored: 10,
+		}
+
+		var got Config
+		provenance, err := Merge(&got, file, env, flags)
+		if err != nil {
+			t.Fatalf("Merge(&got, file, env, flags) = %v. Expected %v.", err, nil)
+		}
+		if got.Server.Host.ptr == nil || *got.Server.Host.ptr != "localhost" {
+			t.Errorf("got.Server.Host = %+v. Expected %v.", got.Server.Host, "localhost")
+		}
+		if got.Server.Port.ptr == nil || *got.Server.Port.ptr != 8080 {
+			t.Errorf("got.Server.Port = %+v. Expected %v.", got.Server.Port, 8080)
+		}
+		if got.Admin == nil || got.Admin.Port.ptr == nil || *got.Admin.Port.ptr != 9090 {
+			t.Errorf("got.Admin = %+v. Expected port %v.", got.Admin, 9090)
+		} else if got.Admin.Host.present == true {
+			t.Error("got.Admin.Host.IsPresent() = true. Expected false.")
+		}
+		if got.Debug.ptr == nil || *got.Debug.ptr != false {
+			t.Errorf("got.Debug = %+v. Expected %v.", got.Debug, false)
+		}
+		if got.Name.ptr != nil || got.Name.present == false {
+			t.Errorf("got.Name = %+v. Expected present null.", got.Name)
+		}
+		if got.Limit == nil || got.Limit.ptr == nil || *got.Limit.ptr != 5 {
+			t.Errorf("got.Limit = %+v. Expected %v.", got.Limit, 5)
+		}
+		if got.Ignored != 0 {
+			t.Errorf("got.Ignored = %v. Expected %v.", got.Ignored, 0)
+		}
+
+		want := Provenance{
+			"Server.Host": 0,
+			"Server.Port": 1,
+			"Admin.Port":  2,
+			"Debug":       2,
+			"Name":        1,
+			"Limit":       2,
+		}
+		if !reflect.DeepEqual(provenance, want) {
+			t.Errorf("provenance = %v. Expected %v.", provenance, want)
+		}
+
+		*file.Server.Host.ptr = "changed"
+		*flags.Limit.ptr = 6
+		if *got.Server.Host.ptr != "localhost" || *got.Limit.ptr != 5 {
+			t.Errorf("got shares values with its layers: Host = %v, Limit = %v. Expected %v, %v.", *got.Server.Host.ptr, *got.Limit.ptr, "localhost", 5)
+		}
+	}
+	{
+		got := Config{Name: From("base")}
+		_, err := Merge(&got, Config{}, (*Config)(nil), nil)
+		if err != nil {
+			t.Errorf("Merge(&got, ...) = %v. Expected %v.", err, nil)
+		}
+		if got.Name.ptr == nil || *got.Name.ptr != "base" {
+			t.Errorf("got.Name = %+v. Expected %v.", got.Name, "base")
+		}
+	}
+	{
+		var got Config
+		if _, err := Merge(&got, Server{}); err == nil {
+			t.Errorf("Merge(&got, Server{}) = %v. Expected error.", err)
+		}
+		if _, err := Merge(got); err == nil {
+			t.Errorf("Merge(got) = %v. Expected error.", err)
+		}
+	}
+}
+
+func TestProvenancePaths(t *testing.T) {
+	provenance := Provenance{"b": 1, "a.c": 0, "a": 2}
+	got := provenance.Paths()
+	want := []string{"a", "a.c", "b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("provenance.Paths() = %v. Expected %v.", got, want)
+	}
+}
@@ -6,6 +6,7 @@ package nullable
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 )
 
 /*
@@ -15,8 +16,9 @@ As such, it implements the Marhsaler and Unmarshaler interfaces.
 It is also possible to use this type with the validator package.
 */
 type Nullable[T any] struct {
-	ptr     *T
-	present bool
+	ptr       *T
+	present   bool
+	defaulted bool
 }
 
 /*
@@ -67,6 +69,22 @@ func (n Nullable[T]) IsAbsent() bool {
 	return !n.present
 }
 
+/*
+IsZero returns true if the Nullable is absent.
+Encoders that support omitting zero values, such as the omitempty option of the MongoDB driver and yaml.v3 or the omitzero option of encoding/json, use it to omit absent Nullables while keeping nulls.
+*/
+func (n Nullable[T]) IsZero() bool {
+	return !n.present
+}
+
+/*
+IsDefaulted returns true if the value held by the Nullable was filled in by ApplyDefaults rather than set or decoded.
+A defaulted Nullable is also present.
+*/
+func (n Nullable[T]) IsDefaulted() bool {
+	return n.defaulted
+}
+
 /*
 Value returns the value held by the Nullable.
 If the Nullable is null, Value panics with a default message.
@@ -144,6 +162,7 @@ A pointer to the held value is returned.
 func (n *Nullable[T]) Set(value T) *T {
 	n.ptr = &value
 	n.present = true
+	n.defaulted = false
 	return n.ptr
 }
 
@@ -153,18 +172,32 @@ Clear removes the stored value and marks the Nullable as present.
 func (n *Nullable[T]) Clear() {
 	n.ptr = nil
 	n.present = true
+	n.defaulted = false
 }
 
 /*
 UnmarshalJSON implements the json.Unmarshaler interface.
 Calls to UnmarshalJSON always mark the Nullable as present.
+If the JSON can't be decoded, the Nullable is left null and an *UnmarshalError is returned.
+Primitive types and time.Time are decoded without reflection when possible, with the same results as encoding/json.
 */
 func (n *Nullable[T]) UnmarshalJSON(raw []byte) error {
 	n.present = true
+	n.defaulted = false
+	if n.unmarshalJSONFast(raw) {
+		return nil
+	}
+	return n.unmarshalJSONGeneric(raw)
+}
+
+/*
+unmarshalJSONGeneric decodes raw with encoding/json.
+*/
+func (n *Nullable[T]) unmarshalJSONGeneric(raw []byte) error {
 	err := json.Unmarshal(raw, &n.ptr)
 	if err != nil {
 		n.ptr = nil
-		return err
+		return newUnmarshalError(raw, reflect.TypeOf((*T)(nil)).Elem(), err)
 	}
 	return nil
 }
@@ -172,11 +205,17 @@ func (n *Nullable[T]) UnmarshalJSON(raw []byte) error {
 /*
 MarshalJSON implements the json.Marshaler interface.
 Whether or not the Nullable is marked as present has no effect on MarshalJSON.
+Primitive types are encoded without reflection, see AppendJSON.
 */
 func (n Nullable[T]) MarshalJSON() ([]byte, error) {
 	if n.ptr == nil {
 		return []byte("null"), nil
 	}
+	if isJSONFast(n.ptr) {
+		if out, ok, err := n.appendJSONFast(make([]byte, 0, 32)); ok {
+			return out, err
+		}
+	}
 	return json.Marshal(*n.ptr)
 }
 
@@ -184,19 +223,19 @@ func (n Nullable[T]) MarshalJSON() ([]byte, error) {
 From creates a new Nullable that holds the provided value.
 */
 func From[T any](val T) Nullable[T] {
-	return Nullable[T]{&val, true}
+	return Nullable[T]{ptr: &val, present: true}
 }
 
 /*
 Null creates a new Nullable that is marked present and holds no value.
 */
 func Null[T any]() Nullable[T] {
-	return Nullable[T]{nil, true}
+	return Nullable[T]{ptr: nil, present: true}
 }
 
 /*
 Absent creates a new Nullable that is marked absent and holds no value.
 */
 func Absent[T any]() Nullable[T] {
-	return Nullable[T]{nil, false}
+	return Nullable[T]{ptr: nil, present: false}
 }
@@ -11,7 +11,7 @@ func TestFrom(t *testing.T) {
 	{
 		got := From(10)
 		tmp := 10
-		want := Nullable[int]{&tmp, true}
+		want := Nullable[int]{ptr: &tmp, present: true}
 		if got.ptr == nil {
 			t.Error("got.IsNull() = true. Wanted false.")
 		} else if *got.ptr != *want.ptr {
@@ -21,7 +21,7 @@ func TestFrom(t *testing.T) {
 	{
 		got := From(true)
 		tmp := true
-		want := Nullable[bool]{&tmp, true}
+		want := Nullable[bool]{ptr: &tmp, present: true}
 		if got.ptr == nil {
 			t.Error("got.IsNull() = true. Wanted false.")
 		} else if *got.ptr != *want.ptr {
@@ -31,7 +31,7 @@ func TestFrom(t *testing.T) {
 	{
 		got := From("hello")
 		tmp := "hello"
-		want := Nullable[string]{&tmp, true}
+		want := Nullable[string]{ptr: &tmp, present: true}
 		if got.ptr == nil {
 			t.Error("got.IsNull() = true. Wanted false.")
 		} else if *got.ptr != *want.ptr {
@@ -105,40 +105,40 @@ func TestAbsent(t *testing.T) {
 
 func TestIsNull(t *testing.T) {
 	{
-		got := Nullable[int]{nil, true}
+		got := Nullable[int]{ptr: nil, present: true}
 		if got.IsNull() == false {
 			t.Error("got.IsNull() = false. Wanted true.")
 		}
 	}
 	{
-		got := Nullable[bool]{nil, true}
+		got := Nullable[bool]{ptr: nil, present: true}
 		if got.IsNull() == false {
 			t.Error("got.IsNull() = false. Wanted true.")
 		}
 	}
 	{
-		got := Nullable[string]{nil, true}
+		got := Nullable[string]{ptr: nil, present: true}
 		if got.IsNull() == false {
 			t.Error("got.IsNull() = false. Wanted true.")
 		}
 	}
 	{
 		tmp := 10
-		got := Nullable[int]{&tmp, true}
+		got := Nullable[int]{ptr: &tmp, present: true}
 		if got.IsNull() == true {
 			t.Error("got.IsNull() = true. Wanted false.")
 		}
 	}
 	{
 		tmp := true
-		got := Nullable[bool]{&tmp, true}
+		got := Nullable[bool]{ptr: &tmp, present: true}
 		if got.IsNull() == true {
 			t.Error("got.IsNull() = true. Wanted false.")
 		}
 	}
 	{
 		tmp := "hello"
-		got := Nullable[string]{&tmp, true}
+		got := Nullable[string]{ptr: &tmp, present: true}
 		if got.IsNull() == true {
 			t.Error("got.IsNull() = true. Wanted false.")
 		}
@@ -147,40 +147,40 @@ func TestIsNull(t *testing.T) {
 
 func TestHasValue(t *testing.T) {
 	{
-		got := Nullable[int]{nil, true}
+		got := Nullable[int]{ptr: nil, present: true}
 		if got.HasValue() == true {
 			t.Error("got.HasValue() = true. Wanted false.")
 		}
 	}
 	{
-		got := Nullable[bool]{nil, true}
+		got := Nullable[bool]{ptr: nil, present: true}
 		if got.HasValue() == true {
 			t.Error("got.HasValue() = true. Wanted false.")
 		}
 	}
 	{
-		got := Nullable[string]{nil, true}
+		got := Nullable[string]{ptr: nil, present: true}
 		if got.HasValue() == true {
 			t.Error("got.HasValue() = true. Wanted false.")
 		}
 	}
 	{
 		tmp := 10
-		got := Nullable[int]{&tmp, true}
+		got := Nullable[int]{ptr: &tmp, present: true}
 		if got.HasValue() == false {
 			t.Error("got.HasValue() = false. Wanted true.")
 		}
 	}
 	{
 		tmp := true
-		got := Nullable[bool]{&tmp, true}
+		got := Nullable[bool]{ptr: &tmp, present: true}
 		if got.HasValue() == false {
 			t.Error("got.HasValue() = false. Wanted true.")
 		}
 	}
 	{
 		tmp := "hello"
-		got := Nullable[string]{&tmp, true}
+		got := Nullable[string]{ptr: &tmp, present: true}
 		if got.HasValue() == false {
 			t.Error("got.HasValue() = false. Wanted true.")
 		}
@@ -189,13 +189,13 @@ func TestHasValue(t *testing.T) {
 
 func TestIsPresent(t *testing.T) {
 	{
-		got := Nullable[int]{nil, false}
+		got := Nullable[int]{ptr: nil, present: false}
 		if got.IsPresent() == true {
 			t.Error("got.IsPresent() = true. Wanted false.")
 		}
 	}
 	{
-		got := Nullable[int]{nil, true}
+		got := Nullable[int]{ptr: nil, present: true}
 		if got.IsPresent() == false {
 			t.Error("got.IsPresent() = false. Wanted true.")
 		}
@@ -204,13 +204,13 @@ func TestIsPresent(t *testing.T) {
 
 func TestIsAbsent(t *testing.T) {
 	{
-		got := Nullable[int]{nil, false}
+		got := Nullable[int]{ptr: nil, present: false}
 		if got.IsAbsent() == false {
 			t.Error("got.IsAbsent() = false. Wanted true.")
 		}
 	}
 	{
-		got := Nullable[int]{nil, true}
+		got := Nullable[int]{ptr: nil, present: true}
 		if got.IsAbsent() == true {
 			t.Error("got.IsAbsent() = true. Wanted false.")
 		}
@@ -226,7 +226,7 @@ func TestValue(t *testing.T) {
 			}
 		}()
 		tmp := 10
-		got := Nullable[int]{&tmp, true}
+		got := Nullable[int]{ptr: &tmp, present: true}
 		if got.Value() != tmp {
 			t.Errorf("got.Value() = %v. Wanted %v", got.Value(), tmp)
 		}
@@ -238,7 +238,7 @@ func TestValue(t *testing.T) {
 			}
 		}()
 		tmp := true
-		got := Nullable[bool]{&tmp, true}
+		got := Nullable[bool]{ptr: &tmp, present: true}
 		if got.Value() != tmp {
 			t.Errorf("got.Value() = %v. Wanted %v", got.Value(), tmp)
 		}
@@ -250,7 +250,7 @@ func TestValue(t *testing.T) {
 			}
 		}()
 		tmp := "hello"
-		got := Nullable[string]{&tmp, true}
+		got := Nullable[string]{ptr: &tmp, present: true}
 		if got.Value() != tmp {
 			t.Errorf("got.Value() = %v. Wanted %v", got.Value(), tmp)
 		}
@@ -258,19 +258,19 @@ func TestValue(t *testing.T) {
 	// These next calls are supposed to panic
 	func() {
 		defer func() { recover() }()
-		got := Nullable[int]{nil, true}
+		got := Nullable[int]{ptr: nil, present: true}
 		got.Value()
 		t.Errorf("got.Value() failed to panic.")
 	}()
 	func() {
 		defer func() { recover() }()
-		got := Nullable[bool]{nil, true}
+		got := Nullable[bool]{ptr: nil, present: true}
 		got.Value()
 		t.Errorf("got.Value() failed to panic.")
 	}()
 	func() {
 		defer func() { recover() }()
-		got := Nullable[string]{nil, true}
+		got := Nullable[string]{ptr: nil, present: true}
 		got.Value()
 		t.Errorf("got.Value() failed to panic.")
 	}()
@@ -285,7 +285,7 @@ func TestExpect(t *testing.T) {
 			}
 		}()
 		tmp := 10
-		got := Nullable[int]{&tmp, true}
+		got := Nullable[int]{ptr: &tmp, present: true}
 		if got.Expect("hello") != tmp {
 			t.Errorf("got.Expect(\"hello\") = %v. Wanted %v", got.Expect("hello"), tmp)
 		}
@@ -297,7 +297,7 @@ func TestExpect(t *testing.T) {
 			}
 		}()
 		tmp := true
-		got := Nullable[bool]{&tmp, true}
+		got := Nullable[bool]{ptr: &tmp, present: true}
 		if got.Expect("hello") != tmp {
 			t.Errorf("got.Expect(\"hello\") = %v. Wanted %v", got.Expect("hello"), tmp)
 		}
@@ -309,7 +309,7 @@ func TestExpect(t *testing.T) {
 			}
 		}()
 		tmp := "hello"
-		got := Nullable[string]{&tmp, true}
+		got := Nullable[string]{ptr: &tmp, present: true}
 		if got.Expect("hello") != tmp {
 			t.Errorf("got.Expect(\"hello\") = %v. Wanted %v", got.Expect("hello"), tmp)
 		}
@@ -323,7 +323,7 @@ func TestExpect(t *testing.T) {
 				}
 			}
 		}()
-		got := Nullable[int]{nil, true}
+		got := Nullable[int]{ptr: nil, present: true}
 		got.Expect("hello")
 		t.Errorf("got.Expect(\"hello\") failed to panic.")
 	}()
@@ -335,7 +335,7 @@ func TestExpect(t *testing.T) {
 				}
 			}
 		}()
-		got := Nullable[bool]{nil, true}
+		got := Nullable[bool]{ptr: nil, present: true}
 		got.Expect("hello")
 		t.Errorf("got.Expect(\"hello\") failed to panic.")
 	}()
@@ -347,7 +347,7 @@ func TestExpect(t *testing.T) {
 				}
 			}
 		}()
-		got := Nullable[string]{nil, true}
+		got := Nullable[string]{ptr: nil, present: true}
 		got.Expect("hello")
 		t.Errorf("got.Expect(\"hello\") failed to panic.")
 	}()
@@ -356,39 +356,39 @@ func TestExpect(t *testing.T) {
 func TestValueOr(t *testing.T) {
 	{
 		tmp := 10
-		got := Nullable[int]{&tmp, true}
+		got := Nullable[int]{ptr: &tmp, present: true}
 		if got.ValueOr(100) != tmp {
 			t.Errorf("got.ValueOr(100) = %v. Wanted %v.", got.ValueOr(100), tmp)
 		}
 	}
 	{
 		tmp := true
-		got := Nullable[bool]{&tmp, true}
+		got := Nullable[bool]{ptr: &tmp, present: true}
 		if got.ValueOr(false) != tmp {
 			t.Errorf("got.ValueOr(false) = %v. Wanted %v.", got.ValueOr(false), tmp)
 		}
 	}
 	{
 		tmp := "hello"
-		got := Nullable[string]{&tmp, true}
+		got := Nullable[string]{ptr: &tmp, present: true}
 		if got.ValueOr("bye") != tmp {
 			t.Errorf("got.ValueOr(\"bye\") = %v. Wanted %v.", got.ValueOr("bye"), tmp)
 		}
 	}
 	{
-		got := Nullable[int]{nil, true}
+		got := Nullable[int]{ptr: nil, present: true}
 		if got.ValueOr(100) != 100 {
 			t.Errorf("got.ValueOr(100) = %v. Wanted %v.", got.ValueOr(100), 100)
 		}
 	}
 	{
-		got := Nullable[bool]{nil, true}
+		got := Nullable[bool]{ptr: nil, present: true}
 		if got.ValueOr(false) != false {
 			t.Errorf("got.ValueOr(false) = %v. Wanted %v.", got.ValueOr(false), false)
 		}
 	}
 	{
-		got := Nullable[string]{nil, true}
+		got := Nullable[string]{ptr: nil, present: true}
 		if got.ValueOr("bye") != "bye" {
 			t.Errorf("got.ValueOr(\"bye\") = %v. Wanted %v.", got.ValueOr("bye"), "bye")
 		}
@@ -398,39 +398,39 @@ func TestValueOr(t *testing.T) {
 func TestValueOrElse(t *testing.T) {
 	{
 		tmp := 10
-		got := Nullable[int]{&tmp, true}
+		got := Nullable[int]{ptr: &tmp, present: true}
 		if got.ValueOrElse(func() int { return 100 }) != tmp {
 			t.Errorf("got.ValueOrElse(func() int { return 100 }) = %v. Wanted %v.", got.ValueOrElse(func() int { return 100 }), tmp)
 		}
 	}
 	{
 		tmp := true
-		got := Nullable[bool]{&tmp, true}
+		got := Nullable[bool]{ptr: &tmp, present: true}
 		if got.ValueOrElse(func() bool { return false }) != tmp {
 			t.Errorf("got.ValueOrElse(func() bool { return false }) = %v. Wanted %v.", got.ValueOrElse(func() bool { return false }), tmp)
 		}
 	}
 	{
 		tmp := "hello"
-		got := Nullable[string]{&tmp, true}
+		got := Nullable[string]{ptr: &tmp, present: true}
 		if got.ValueOrElse(func() string { return "bye" }) != tmp {
 			t.Errorf("got.ValueOrElse(func() string { return \"bye\" }) = %v. Wanted %v.", got.ValueOrElse(func() string { return "bye" }), tmp)
 		}
 	}
 	{
-		got := Nullable[int]{nil, true}
+		got := Nullable[int]{ptr: nil, present: true}
 		if got.ValueOrElse(func() int { return 100 }) != 100 {
 			t.Errorf("got.ValueOrElse(func() int { return 100 }) = %v. Wanted %v.", got.ValueOrElse(func() int { return 100 }), 100)
 		}
 	}
 	{
-		got := Nullable[bool]{nil, true}
+		got := Nullable[bool]{ptr: nil, present: true}
 		if got.ValueOrElse(func() bool { return false }) != false {
 			t.Errorf("got.ValueOrElse(func() bool { return false }) = %v. Wanted %v.", got.ValueOrElse(func() bool { return false }), false)
 		}
 	}
 	{
-		got := Nullable[string]{nil, true}
+		got := Nullable[string]{ptr: nil, present: true}
 		if got.ValueOrElse(func() string { return "bye" }) != "bye" {
 			t.Errorf("got.ValueOrElse(func() string { return \"bye\" }) = %v. Wanted %v.", got.ValueOrElse(func() string { return "bye" }), "bye")
 		}
@@ -440,39 +440,39 @@ func TestValueOrElse(t *testing.T) {
 func TestValueOrDefault(t *testing.T) {
 	{
 		tmp := 10
-		got := Nullable[int]{&tmp, true}
+		got := Nullable[int]{ptr: &tmp, present: true}
 		if got.ValueOrDefault() != tmp {
 			t.Errorf("got.ValueOrDefault() = %v. Wanted %v.", got.ValueOrDefault(), tmp)
 		}
 	}
 	{
 		tmp := true
-		got := Nullable[bool]{&tmp, true}
+		got := Nullable[bool]{ptr: &tmp, present: true}
 		if got.ValueOrDefault() != tmp {
 			t.Errorf("got.ValueOrDefault() = %v. Wanted %v.", got.ValueOrDefault(), tmp)
 		}
 	}
 	{
 		tmp := "hello"
-		got := Nullable[string]{&tmp, true}
+		got := Nullable[string]{ptr: &tmp, present: true}
 		if got.ValueOrDefault() != tmp {
 			t.Errorf("got.ValueOrDefault() = %v. Wanted %v.", got.ValueOrDefault(), tmp)
 		}
 	}
 	{
-		got := Nullable[int]{nil, true}
+		got := Nullable[int]{ptr: nil, present: true}
 		if got.ValueOrDefault() != 0 {
 			t.Errorf("got.ValueOrDefault() = %v. Wanted %v.", got.ValueOrDefault(), 0)
 		}
 	}
 	{
-		got := Nullable[bool]{nil, true}
+		got := Nullable[bool]{ptr: nil, present: true}
 		if got.ValueOrDefault() != false {
 			t.Errorf("got.ValueOrDefault() = %v. Wanted %v.", got.ValueOrDefault(), false)
 		}
 	}
 	{
-		got := Nullable[string]{nil, true}
+		got := Nullable[string]{ptr: nil, present: true}
 		if got.ValueOrDefault() != "" {
 			t.Errorf("got.ValueOrDefault() = %v. Wanted %v.", got.ValueOrDefault(), "")
 		}
@@ -482,39 +482,39 @@ func TestValueOrDefault(t *testing.T) {
 func TestTryValue(t *testing.T) {
 	{
 		tmp := 10
-		got := Nullable[int]{&tmp, true}
+		got := Nullable[int]{ptr: &tmp, present: true}
 		if value, err := got.TryValue(); value != tmp {
 			t.Errorf("got.TryValue() = %v, %v. Wanted %v, %v.", value, err, 10, nil)
 		}
 	}
 	{
 		tmp := true
-		got := Nullable[bool]{&tmp, true}
+		got := Nullable[bool]{ptr: &tmp, present: true}
 		if value, err := got.TryValue(); value != tmp {
 			t.Errorf("got.TryValue() = %v, %v. Wanted %v, %v.", value, err, true, nil)
 		}
 	}
 	{
 		tmp := "hello"
-		got := Nullable[string]{&tmp, true}
+		got := Nullable[string]{ptr: &tmp, present: true}
 		if value, err := got.TryValue(); value != tmp {
 			t.Errorf("got.TryValue() = %v, %v. Wanted %v, %v.", value, err, "hello", nil)
 		}
 	}
 	{
-		got := Nullable[int]{nil, true}
+		got := Nullable[int]{ptr: nil, present: true}
 		if value, err := got.TryValue(); value != 0 {
 			t.Errorf("got.TryValue() = %v, %v. Wanted %v, error.", value, err, 0)
 		}
 	}
 	{
-		got := Nullable[bool]{nil, true}
+		got := Nullable[bool]{ptr: nil, present: true}
 		if value, err := got.TryValue(); value != false {
 			t.Errorf("got.TryValue() = %v, %v. Wanted %v, error.", value, err, false)
 		}
 	}
 	{
-		got := Nullable[string]{nil, true}
+		got := Nullable[string]{ptr: nil, present: true}
 		if value, err := got.TryValue(); value != "" {
 			t.Errorf("got.TryValue() = %v, %v. Wanted %v, error.", value, err, "")
 		}
@@ -523,7 +523,7 @@ func TestTryValue(t *testing.T) {
 
 func TestSet(t *testing.T) {
 	{
-		got := Nullable[int]{nil, false}
+		got := Nullable[int]{ptr: nil, present: false}
 		ptr := got.Set(10)
 		if got.ptr == nil {
 			t.Error("got.IsNull() = true. Expected false.")
@@ -547,7 +547,7 @@ func TestSet(t *testing.T) {
 		}
 	}
 	{
-		got := Nullable[bool]{nil, false}
+		got := Nullable[bool]{ptr: nil, present: false}
 		ptr := got.Set(true)
 		if got.ptr == nil {
 			t.Error("got.IsNull() = true. Expected false.")
@@ -571,7 +571,7 @@ func TestSet(t *testing.T) {
 		}
 	}
 	{
-		got := Nullable[string]{nil, false}
+		got := Nullable[string]{ptr: nil, present: false}
 		ptr := got.Set("hello")
 		if got.ptr == nil {
 			t.Error("got.IsNull() = true. Expected false.")
@@ -599,7 +599,7 @@ func TestSet(t *testing.T) {
 func TestClear(t *testing.T) {
 	{
 		tmp := 10
-		got := Nullable[int]{&tmp, false}
+		got := Nullable[int]{ptr: &tmp, present: false}
 		got.Clear()
 		if got.ptr != nil {
 			t.Error("got.IsNull() = false. Expected true.")
@@ -610,7 +610,7 @@ func TestClear(t *testing.T) {
 	}
 	{
 		tmp := true
-		got := Nullable[bool]{&tmp, false}
+		got := Nullable[bool]{ptr: &tmp, present: false}
 		got.Clear()
 		if got.ptr != nil {
 			t.Error("got.IsNull() = false. Expected true.")
@@ -621,7 +621,7 @@ func TestClear(t *testing.T) {
 	}
 	{
 		tmp := "hello"
-		got := Nullable[string]{&tmp, false}
+		got := Nullable[string]{ptr: &tmp, present: false}
 		got.Clear()
 		if got.ptr != nil {
 			t.Error("got.IsNull() = false. Expected true.")
@@ -839,7 +839,7 @@ func TestUnmarshalJSON(t *testing.T) {
 func TestMarshalJSON(t *testing.T) {
 	{
 		tmp := 10
-		got := Nullable[int]{&tmp, true}
+		got := Nullable[int]{ptr: &tmp, present: true}
 		j, err := json.Marshal(got)
 		if err != nil {
 			t.Errorf("json.Marshal(got) err = %v. Expected nil.", err)
@@ -849,7 +849,7 @@ func TestMarshalJSON(t *testing.T) {
 	}
 	{
 		tmp := true
-		got := Nullable[bool]{&tmp, true}
+		got := Nullable[bool]{ptr: &tmp, present: true}
 		j, err := json.Marshal(got)
 		if err != nil {
 			t.Errorf("json.Marshal(got) err = %v. Expected nil.", err)
@@ -859,7 +859,7 @@ func TestMarshalJSON(t *testing.T) {
 	}
 	{
 		tmp := "hello"
-		got := Nullable[string]{&tmp, true}
+		got := Nullable[string]{ptr: &tmp, present: true}
 		j, err := json.Marshal(got)
 		if err != nil {
 			t.Errorf("json.Marshal(got) err = %v. Expected nil.", err)
@@ -868,7 +868,7 @@ func TestMarshalJSON(t *testing.T) {
 		}
 	}
 	{
-		got := Nullable[int]{nil, true}
+		got := Nullable[int]{ptr: nil, present: true}
 		j, err := json.Marshal(got)
 		if err != nil {
 			t.Errorf("json.Marshal(got) err = %v. Expected nil.", err)
@@ -877,7 +877,7 @@ func TestMarshalJSON(t *testing.T) {
 		}
 	}
 	{
-		got := Nullable[bool]{nil, true}
+		got := Nullable[bool]{ptr: nil, present: true}
 		j, err := json.Marshal(got)
 		if err != nil {
 			t.Errorf("json.Marshal(got) err = %v. Expected nil.", err)
@@ -886,7 +886,7 @@ func TestMarshalJSON(t *testing.T) {
 		}
 	}
 	{
-		got := Nullable[string]{nil, true}
+		got := Nullable[string]{ptr: nil, present: true}
 		j, err := json.Marshal(got)
 		if err != nil {
 			t.Errorf("json.Marshal(got) err = %v. Expected nil.", err)
@@ -895,3 +895,9 @@ func TestMarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestIsZero(t *testing.T) {
+	if !Absent[int]().IsZero() || Null[int]().IsZero() || From(0).IsZero() {
+		t.Errorf("IsZero() = %v, %v, %v. Expected true, false, false.", Absent[int]().IsZero(), Null[int]().IsZero(), From(0).IsZero())
+	}
+}
@@ -0,0 +1,231 @@
+/*
+Package nullablebson encodes Nullables with the MongoDB driver and builds update documents from structs of Nullables.
+Null Nullables are encoded as BSON null and values as their native BSON type.
+Decoding always marks a Nullable as present, and BSON null and undefined make it null, while fields missing from a document leave it absent.
+Tag fields with omitempty to leave absent Nullables out of documents.
+*/
+package nullablebson
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+/*
+nullableValue is implemented by any Nullable.
+*/
+type nullableValue interface {
+	IsPresent() bool
+	IsNull() bool
+	IsDefaulted() bool
+}
+
+/*
+nullableField is implemented by a pointer to any Nullable.
+*/
+type nullableField interface {
+	IsDefaulted() bool
+	Clear()
+}
+
+var (
+	nullableValueType = reflect.TypeOf((*nullableValue)(nil)).Elem()
+	nullableFieldType = reflect.TypeOf((*nullableField)(nil)).Elem()
+)
+
+/*
+Register registers an encoder and a decoder for every Nullable with r.
+The driver caches the codecs it looks up, so Register must be called before r is first used.
+
+	registry := bson.NewRegistry()
+	nullablebson.Register(registry)
+	client, _ := mongo.Connect(ctx, options.Client().ApplyURI(uri).SetRegistry(registry))
+*/
+func Register(r *bsoncodec.Registry) {
+	r.RegisterInterfaceEncoder(nullableValueType, bsoncodec.ValueEncoderFunc(encodeNullable))
+	r.RegisterInterfaceDecoder(nullableFieldType, bsoncodec.ValueDecoderFunc(decodeNullable))
+}
+
+/*
+NewRegistry returns a registry holding the driver's default codecs and the codecs registered by Register.
+*/
+func NewRegistry() *bsoncodec.Registry {
+	r := bson.NewRegistry()
+	Register(r)
+	return r
+}
+
+/*
+encodeNullable encodes the Nullable or pointer to a Nullable val.
+*/
+func encodeNullable(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return vw.WriteNull()
+		}
+		val = val.Elem()
+	}
+	if val.Interface().(nullableValue).IsNull() {
+		return vw.WriteNull()
+	}
+
+	value := val.MethodByName("ValueOrDefault").Call(nil)[0]
+	encoder, err := ec.LookupEncoder(value.Type())
+	if err != nil {
+		return err
+	}
+	return encoder.EncodeValue(ec, vw, value)
+}
+
+/*
+decodeNullable decodes into the addressable Nullable or pointer to a Nullable val.
+If the value can't be decoded, the Nullable is left null.
+*/
+func decodeNullable(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			if !val.CanSet() {
+				return bsoncodec.ValueDecoderError{Name: "nullablebson.decodeNullable", Types: []reflect.Type{val.Type()}, Received: val}
+			}
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		val = val.Elem()
+	}
+	if !val.CanAddr() {
+		return bsoncodec.ValueDecoderError{Name: "nullablebson.decodeNullable", Types: []reflect.Type{val.Type()}, Received: val}
+	}
+
+	field := val.Addr().Interface().(nullableField)
+	switch vr.Type() {
+	case bsontype.Null:
+		field.Clear()
+		return vr.ReadNull()
+	case bsontype.Undefined:
+		field.Clear()
+		return vr.ReadUndefined()
+	}
+
+	set := val.Addr().MethodByName("Set")
+	value := reflect.New(set.Type().In(0)).Elem()
+	decoder, err := dc.LookupDecoder(value.Type())
+	if err == nil {
+		err = decoder.DecodeValue(dc, vr, value)
+	}
+	if err != nil {
+		field.Clear()
+		return err
+	}
+	set.Call([]reflect.Value{value})
+	return nil
+}
+
+/*
+BSONUpdate holds the options used to build MongoDB update documents.
+The zero value is ready to use.
+*/
+type BSONUpdate struct {
+	// SetNulls sets null fields to BSON null instead of removing them with $unset.
+	SetNulls bool
+}
+
+/*
+UpdateDocument builds an update document from v using a zero BSONUpdate.
+*/
+func UpdateDocument(v any) (bson.D, error) {
+	return BSONUpdate{}.Document(v)
+}
+
+/*
+Document builds a MongoDB update document from the present Nullable fields of the struct v.
+Fields holding values are added to $set and null fields to $unset, or to $set as nil if SetNulls is true, while absent fields are left out.
+The values in $set are the values held by the fields rather than the Nullables, so the document only needs the codecs of Register if those values hold Nullables themselves.
+Keys follow the bson tags of the fields, with the driver's default of the lowercased field name.
+Pointers to Nullables are followed, and a nil pointer is treated as absent.
+Nested structs and pointers to structs add their key and a dot to the keys of their fields, unless they are inlined.
+
+	type UserPatch struct {
+		Name    nullable.Nullable[string] `bson:"name"`
+		Email   nullable.Nullable[string] `bson:"email"`
+		Address struct {
+			City nullable.Nullable[string] `bson:"city"`
+		} `bson:"address"`
+	}
+
+	update, _ := nullablebson.UpdateDocument(patch)
+	// {"$set": {"name": "Ann", "address.city": "Oslo"}, "$unset": {"email": ""}}
+	collection.UpdateByID(ctx, id, update)
+
+If no field is present, the returned document is empty, which MongoDB rejects as an update.
+*/
+func (b BSONUpdate) Document(v any) (bson.D, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New("BSONUpdate.Document() called with a non-struct value")
+	}
+
+	var set, unset bson.D
+	b.collect(rv, "", &set, &unset)
+
+	update := bson.D{}
+	if len(set) > 0 {
+		update = append(update, bson.E{Key: "$set", Value: set})
+	}
+	if len(unset) > 0 {
+		update = append(update, bson.E{Key: "$unset", Value: unset})
+	}
+	return update, nil
+}
+
+func (b BSONUpdate) collect(rv reflect.Value, prefix string, set, unset *bson.D) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag := field.Tag.Get("bson")
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		key := prefix + name
+
+		fv := rv.Field(i)
+		if fv.Kind() == reflect.Pointer && fv.Type().Elem().Kind() == reflect.Struct {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() != reflect.Struct {
+			continue
+		}
+
+		if n, ok := fv.Interface().(nullableValue); ok {
+			switch {
+			case !n.IsPresent():
+			case n.IsNull() && !b.SetNulls:
+				*unset = append(*unset, bson.E{Key: key, Value: ""})
+			case n.IsNull():
+				*set = append(*set, bson.E{Key: key, Value: nil})
+			default:
+				*set = append(*set, bson.E{Key: key, Value: fv.MethodByName("ValueOrDefault").Call(nil)[0].Interface()})
+			}
+			continue
+		}
+		nested := key + "."
+		if strings.Contains(","+options+",", ",inline,") {
+			nested = prefix
+		}
+		b.collect(fv, nested, set, unset)
+	}
+}
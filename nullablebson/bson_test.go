@@ -0,0 +1,156 @@
+package nullablebson
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/missingsemi/nullable"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type testAddress struct {
+	City nullable.Nullable[string] `bson:"city,omitempty"`
+}
+
+type testUser struct {
+	Name    nullable.Nullable[string]      `bson:"name,omitempty"`
+	Email   nullable.Nullable[string]      `bson:"email,omitempty"`
+	Age     nullable.Nullable[int]         `bson:"age,omitempty"`
+	Seen    nullable.Nullable[time.Time]   `bson:"seen,omitempty"`
+	Home    nullable.Nullable[testAddress] `bson:"home,omitempty"`
+	Tags    nullable.Nullable[[]string]    `bson:"tags,omitempty"`
+	Always  nullable.Nullable[bool]        `bson:"always"`
+	Pointer *nullable.Nullable[int]        `bson:"pointer"`
+	Ignored nullable.Nullable[int]         `bson:"-"`
+}
+
+func TestMarshalBSON(t *testing.T) {
+	registry := NewRegistry()
+	seen := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	pointer := nullable.From(7)
+	user := testUser{
+		Name:    nullable.From("Ann"),
+		Email:   nullable.Null[string](),
+		Seen:    nullable.From(seen),
+		Home:    nullable.From(testAddress{City: nullable.From("Oslo")}),
+		Tags:    nullable.From([]string{"a"}),
+		Pointer: &pointer,
+		Ignored: nullable.From(1),
+	}
+	data, err := bson.MarshalWithRegistry(registry, user)
+	if err != nil {
+		t.Fatalf("bson.MarshalWithRegistry() err = %v. Expected nil.", err)
+	}
+
+	var doc bson.D
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("bson.Unmarshal() err = %v. Expected nil.", err)
+	}
+	var keys []string
+	for _, e := range doc {
+		keys = append(keys, e.Key)
+	}
+	if expected := []string{"name", "email", "seen", "home", "tags", "always", "pointer"}; !reflect.DeepEqual(keys, expected) {
+		t.Errorf("bson.MarshalWithRegistry() keys = %v. Expected %v.", keys, expected)
+	}
+	raw := bson.Raw(data)
+	if v := raw.Lookup("email"); v.Type != bson.TypeNull {
+		t.Errorf("bson.MarshalWithRegistry() email = %v. Expected null.", v)
+	}
+	if v := raw.Lookup("seen"); v.Type != bson.TypeDateTime {
+		t.Errorf("bson.MarshalWithRegistry() seen type = %v. Expected %v.", v.Type, bson.TypeDateTime)
+	}
+	if v := raw.Lookup("home", "city"); v.StringValue() != "Oslo" {
+		t.Errorf("bson.MarshalWithRegistry() home.city = %v. Expected Oslo.", v)
+	}
+	if v := raw.Lookup("pointer"); v.AsInt64() != 7 {
+		t.Errorf("bson.MarshalWithRegistry() pointer = %v. Expected 7.", v)
+	}
+
+	var got testUser
+	if err := bson.UnmarshalWithRegistry(registry, data, &got); err != nil {
+		t.Fatalf("bson.UnmarshalWithRegistry() err = %v. Expected nil.", err)
+	}
+	if got.Name.ValueOrDefault() != "Ann" || !got.Email.IsPresent() || !got.Email.IsNull() || got.Age.IsPresent() {
+		t.Errorf("bson.UnmarshalWithRegistry() = %+v.", got)
+	}
+	if !got.Seen.ValueOrDefault().Equal(seen) || got.Home.ValueOrDefault().City.ValueOrDefault() != "Oslo" || !reflect.DeepEqual(got.Tags.ValueOrDefault(), []string{"a"}) {
+		t.Errorf("bson.UnmarshalWithRegistry() = %+v.", got)
+	}
+	if !got.Always.IsPresent() || !got.Always.IsNull() || got.Ignored.IsPresent() {
+		t.Errorf("bson.UnmarshalWithRegistry() = %+v.", got)
+	}
+	if got.Pointer == nil || got.Pointer.ValueOrDefault() != 7 {
+		t.Errorf("bson.UnmarshalWithRegistry() Pointer = %v. Expected 7.", got.Pointer)
+	}
+
+	var mismatched struct {
+		Name nullable.Nullable[int] `bson:"name"`
+	}
+	if err := bson.UnmarshalWithRegistry(registry, data, &mismatched); err == nil {
+		t.Errorf("bson.UnmarshalWithRegistry() into a mismatched type err = nil. Expected an error.")
+	}
+	if !mismatched.Name.IsPresent() || !mismatched.Name.IsNull() {
+		t.Errorf("bson.UnmarshalWithRegistry() into a mismatched type = %+v. Expected a present null.", mismatched.Name)
+	}
+}
+
+func TestUpdateDocument(t *testing.T) {
+	type Audit struct {
+		By nullable.Nullable[string] `bson:"by"`
+	}
+	type Patch struct {
+		Audit   `bson:",inline"`
+		Name    nullable.Nullable[string]
+		Email   nullable.Nullable[string]  `bson:"email"`
+		Age     nullable.Nullable[int]     `bson:"age"`
+		Address *testAddress               `bson:"address"`
+		Count   int                        `bson:"count"`
+		Role    *nullable.Nullable[string] `bson:"role"`
+		Team    *nullable.Nullable[string] `bson:"team"`
+	}
+	role := nullable.Null[string]()
+	patch := Patch{
+		Audit:   Audit{By: nullable.From("admin")},
+		Name:    nullable.From("Ann"),
+		Email:   nullable.Null[string](),
+		Address: &testAddress{City: nullable.From("Oslo")},
+		Count:   1,
+		Role:    &role,
+	}
+
+	got, err := UpdateDocument(&patch)
+	if err != nil {
+		t.Fatalf("UpdateDocument() err = %v. Expected nil.", err)
+	}
+	expected := bson.D{
+		{Key: "$set", Value: bson.D{{Key: "by", Value: "admin"}, {Key: "name", Value: "Ann"}, {Key: "address.city", Value: "Oslo"}}},
+		{Key: "$unset", Value: bson.D{{Key: "email", Value: ""}, {Key: "role", Value: ""}}},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("UpdateDocument() = %v. Expected %v.", got, expected)
+	}
+
+	got, err = BSONUpdate{SetNulls: true}.Document(patch)
+	if err != nil {
+		t.Fatalf("Document() err = %v. Expected nil.", err)
+	}
+	data, err := bson.Marshal(got)
+	if err != nil {
+		t.Fatalf("bson.Marshal(Document()) err = %v. Expected nil.", err)
+	}
+	if v := bson.Raw(data).Lookup("$set", "email"); v.Type != bson.TypeNull {
+		t.Errorf("Document() $set.email = %v. Expected null.", v)
+	}
+	if v := bson.Raw(data).Lookup("$unset"); v.Type != 0 {
+		t.Errorf("Document() $unset = %v. Expected it to be missing.", v)
+	}
+
+	if got, err := UpdateDocument(Patch{}); err != nil || len(got) != 0 {
+		t.Errorf("UpdateDocument(empty) = %v, %v. Expected [], nil.", got, err)
+	}
+	if _, err := UpdateDocument(1); err == nil {
+		t.Errorf("UpdateDocument(1) err = nil. Expected an error.")
+	}
+}
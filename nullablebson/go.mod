@@ -0,0 +1,10 @@
+module github.com/missingsemi/nullable/nullablebson
+
+go 1.20
+
+require (
+	github.com/missingsemi/nullable v0.0.0
+	go.mongodb.org/mongo-driver v1.17.6
+)
+
+replace github.com/missingsemi/nullable => ../
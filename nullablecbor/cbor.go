@@ -0,0 +1,72 @@
+/*
+Package nullablecbor encodes Nullables with github.com/fxamacker/cbor/v2.
+Absent Nullables are encoded as undefined, null Nullables as null and values with cbor.Marshal.
+Unlike JSON, this keeps absent and null apart inside arrays and maps as well as in structs.
+*/
+package nullablecbor
+
+import (
+	"github.com/fxamacker/cbor/v2"
+	"github.com/missingsemi/nullable"
+)
+
+/*
+cborNull and cborUndefined are the encodings of the CBOR simple values null and undefined.
+*/
+const (
+	cborNull      = 0xf6
+	cborUndefined = 0xf7
+)
+
+/*
+Nullable wraps a nullable.Nullable to implement the cbor.Marshaler and cbor.Unmarshaler interfaces.
+The methods of the wrapped Nullable are promoted, so it is used the same way.
+
+	readings := []nullablecbor.Nullable[float64]{
+		{Nullable: nullable.From(1.5)},
+		{Nullable: nullable.Null[float64]()},
+		{Nullable: nullable.Absent[float64]()},
+	}
+	data, _ := cbor.Marshal(readings)
+	// [1.5, null, undefined]
+*/
+type Nullable[T any] struct {
+	nullable.Nullable[T]
+}
+
+/*
+MarshalCBOR implements the cbor.Marshaler interface.
+*/
+func (n Nullable[T]) MarshalCBOR() ([]byte, error) {
+	if !n.IsPresent() {
+		return []byte{cborUndefined}, nil
+	}
+	if n.IsNull() {
+		return []byte{cborNull}, nil
+	}
+	return cbor.Marshal(n.Value())
+}
+
+/*
+UnmarshalCBOR implements the cbor.Unmarshaler interface.
+Undefined makes the Nullable absent, null makes it present and null, and any other value is decoded with cbor.Unmarshal.
+Fields missing from a map are never decoded, so they also leave the Nullable absent.
+If the value can't be decoded, the Nullable is left null.
+*/
+func (n *Nullable[T]) UnmarshalCBOR(data []byte) error {
+	if len(data) == 1 && data[0] == cborUndefined {
+		n.Nullable = nullable.Absent[T]()
+		return nil
+	}
+	n.Clear()
+	if len(data) == 1 && data[0] == cborNull {
+		return nil
+	}
+
+	var tmp T
+	if err := cbor.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	n.Set(tmp)
+	return nil
+}
@@ -0,0 +1,72 @@
+package nullablecbor
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/missingsemi/nullable"
+)
+
+type testReading struct {
+	Sensor Nullable[string]            `cbor:"sensor"`
+	Value  Nullable[float64]           `cbor:"value"`
+	Unit   Nullable[string]            `cbor:"unit"`
+	Labels Nullable[map[string]string] `cbor:"labels"`
+	Series []Nullable[int]             `cbor:"series"`
+}
+
+func TestMarshalCBOR(t *testing.T) {
+	if data, err := cbor.Marshal(Nullable[int]{nullable.Absent[int]()}); err != nil || !bytes.Equal(data, []byte{0xf7}) {
+		t.Errorf("cbor.Marshal(absent) = %x, %v. Expected f7, nil.", data, err)
+	}
+	if data, err := cbor.Marshal(Nullable[int]{nullable.Null[int]()}); err != nil || !bytes.Equal(data, []byte{0xf6}) {
+		t.Errorf("cbor.Marshal(null) = %x, %v. Expected f6, nil.", data, err)
+	}
+	if data, err := cbor.Marshal(Nullable[int]{nullable.From(1)}); err != nil || !bytes.Equal(data, []byte{0x01}) {
+		t.Errorf("cbor.Marshal(1) = %x, %v. Expected 01, nil.", data, err)
+	}
+	if data, err := cbor.Marshal(Nullable[int]{nullable.From(-1)}); err != nil || !bytes.Equal(data, []byte{0x20}) {
+		t.Errorf("cbor.Marshal(-1) = %x, %v. Expected 20, nil.", data, err)
+	}
+}
+
+func TestCBORRoundTrip(t *testing.T) {
+	reading := testReading{
+		Sensor: Nullable[string]{nullable.From("t1")},
+		Value:  Nullable[float64]{nullable.Null[float64]()},
+		Labels: Nullable[map[string]string]{nullable.From(map[string]string{"room": "a"})},
+		Series: []Nullable[int]{{nullable.From(1)}, {nullable.Null[int]()}, {nullable.Absent[int]()}, {nullable.From(0)}},
+	}
+	data, err := cbor.Marshal(reading)
+	if err != nil {
+		t.Fatalf("cbor.Marshal() err = %v. Expected nil.", err)
+	}
+
+	var got testReading
+	if err := cbor.Unmarshal(data, &got); err != nil {
+		t.Fatalf("cbor.Unmarshal() err = %v. Expected nil.", err)
+	}
+	if !reflect.DeepEqual(got, reading) {
+		t.Errorf("cbor.Unmarshal(cbor.Marshal(v)) = %+v. Expected %+v.", got, reading)
+	}
+	if !got.Value.IsPresent() || !got.Value.IsNull() || got.Unit.IsPresent() {
+		t.Errorf("cbor.Unmarshal() Value = %+v, Unit = %+v. Expected null and absent.", got.Value, got.Unit)
+	}
+
+	var missing testReading
+	if err := cbor.Unmarshal([]byte{0xa0}, &missing); err != nil || missing.Sensor.IsPresent() {
+		t.Errorf("cbor.Unmarshal({}) = %+v, %v. Expected absent fields.", missing, err)
+	}
+
+	var mismatched struct {
+		Sensor Nullable[int] `cbor:"sensor"`
+	}
+	if err := cbor.Unmarshal(data, &mismatched); err == nil {
+		t.Errorf("cbor.Unmarshal() into a mismatched type err = nil. Expected an error.")
+	}
+	if !mismatched.Sensor.IsPresent() || !mismatched.Sensor.IsNull() {
+		t.Errorf("cbor.Unmarshal() into a mismatched type = %+v. Expected a present null.", mismatched.Sensor)
+	}
+}
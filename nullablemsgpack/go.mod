@@ -0,0 +1,12 @@
+module github.com/missingsemi/nullable/nullablemsgpack
+
+go 1.18
+
+require (
+	github.com/missingsemi/nullable v0.0.0
+	github.com/vmihailenco/msgpack/v5 v5.3.5
+)
+
+require github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+
+replace github.com/missingsemi/nullable => ../
@@ -0,0 +1,71 @@
+/*
+Package nullablemsgpack encodes Nullables with github.com/vmihailenco/msgpack/v5.
+Null and absent Nullables are encoded as nil and values with the encoder's own rules, so its options apply to the held value.
+Decoding always marks a Nullable as present, and nil makes it null, while keys missing from a map leave it absent.
+Tag fields with omitempty to leave absent Nullables out of maps.
+*/
+package nullablemsgpack
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/missingsemi/nullable"
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+)
+
+/*
+Register registers an encoder and a decoder for Nullable[T] with the msgpack package.
+The package caches the codecs of struct fields when it first encodes or decodes a struct, so Register must be called for every Nullable type before the structs holding them are used, typically from an init function.
+Without it, a Nullable has no exported fields for msgpack to encode, so it is encoded as an empty map whatever it holds.
+
+	type Job struct {
+		ID       nullable.Nullable[int64]     `msgpack:"id"`
+		Deadline nullable.Nullable[time.Time] `msgpack:"deadline,omitempty"`
+	}
+
+	func init() {
+		nullablemsgpack.Register[int64]()
+		nullablemsgpack.Register[time.Time]()
+	}
+*/
+func Register[T any]() {
+	msgpack.Register(nullable.Nullable[T]{}, encodeNullable[T], decodeNullable[T])
+}
+
+func encodeNullable[T any](enc *msgpack.Encoder, v reflect.Value) error {
+	n := v.Interface().(nullable.Nullable[T])
+	if n.IsNull() {
+		return enc.EncodeNil()
+	}
+	return enc.Encode(n.Value())
+}
+
+/*
+decodeNullable decodes into the addressable Nullable v.
+Unlike the decoders of types implementing msgpack.CustomDecoder, registered decoders are also called for nil, which keeps the Nullable present.
+If the value can't be decoded, the Nullable is left null.
+*/
+func decodeNullable[T any](dec *msgpack.Decoder, v reflect.Value) error {
+	if !v.CanAddr() {
+		return fmt.Errorf("msgpack: Decode(non-addressable %s)", v.Type())
+	}
+	n := v.Addr().Interface().(*nullable.Nullable[T])
+	n.Clear()
+
+	code, err := dec.PeekCode()
+	if err != nil {
+		return err
+	}
+	if code == msgpcode.Nil {
+		return dec.DecodeNil()
+	}
+
+	var tmp T
+	if err := dec.Decode(&tmp); err != nil {
+		return err
+	}
+	n.Set(tmp)
+	return nil
+}
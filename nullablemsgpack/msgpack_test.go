@@ -0,0 +1,101 @@
+package nullablemsgpack
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/missingsemi/nullable"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type testJob struct {
+	ID       nullable.Nullable[int64]          `msgpack:"id"`
+	Name     nullable.Nullable[string]         `msgpack:"name,omitempty"`
+	Note     nullable.Nullable[string]         `msgpack:"note,omitempty"`
+	Deadline nullable.Nullable[time.Time]      `msgpack:"deadline,omitempty"`
+	Tags     nullable.Nullable[[]string]       `msgpack:"tags,omitempty"`
+	Args     nullable.Nullable[map[string]int] `msgpack:"args,omitempty"`
+	Retry    nullable.Nullable[testRetry]      `msgpack:"retry,omitempty"`
+}
+
+type testRetry struct {
+	Max nullable.Nullable[int] `msgpack:"max,omitempty"`
+}
+
+func init() {
+	Register[int64]()
+	Register[string]()
+	Register[time.Time]()
+	Register[[]string]()
+	Register[map[string]int]()
+	Register[testRetry]()
+	Register[int]()
+}
+
+func TestMsgpack(t *testing.T) {
+	deadline := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	job := testJob{
+		Name:     nullable.From("send"),
+		Note:     nullable.Null[string](),
+		Deadline: nullable.From(deadline),
+		Tags:     nullable.From([]string{"a"}),
+		Retry:    nullable.From(testRetry{Max: nullable.From(3)}),
+	}
+	data, err := msgpack.Marshal(job)
+	if err != nil {
+		t.Fatalf("msgpack.Marshal() err = %v. Expected nil.", err)
+	}
+
+	var raw map[string]any
+	if err := msgpack.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("msgpack.Unmarshal() err = %v. Expected nil.", err)
+	}
+	expected := map[string]any{
+		"id":       nil,
+		"name":     "send",
+		"note":     nil,
+		"deadline": deadline.Local(),
+		"tags":     []any{"a"},
+		"retry":    map[string]any{"max": int8(3)},
+	}
+	if !reflect.DeepEqual(raw, expected) {
+		t.Errorf("msgpack.Marshal() = %#v. Expected %#v.", raw, expected)
+	}
+
+	var got testJob
+	if err := msgpack.Unmarshal(data, &got); err != nil {
+		t.Fatalf("msgpack.Unmarshal() err = %v. Expected nil.", err)
+	}
+	if !got.ID.IsPresent() || !got.ID.IsNull() || got.Name.ValueOrDefault() != "send" || !got.Note.IsPresent() || !got.Note.IsNull() {
+		t.Errorf("msgpack.Unmarshal() = %+v.", got)
+	}
+	if !got.Deadline.ValueOrDefault().Equal(deadline) || !reflect.DeepEqual(got.Tags.ValueOrDefault(), []string{"a"}) || got.Args.IsPresent() {
+		t.Errorf("msgpack.Unmarshal() = %+v.", got)
+	}
+	if got.Retry.ValueOrDefault().Max.ValueOrDefault() != 3 {
+		t.Errorf("msgpack.Unmarshal() Retry = %+v.", got.Retry)
+	}
+
+	var n nullable.Nullable[string]
+	if err := msgpack.Unmarshal([]byte{0xc0}, &n); err != nil || !n.IsPresent() || !n.IsNull() {
+		t.Errorf("msgpack.Unmarshal(nil) = %+v, %v. Expected a present null.", n, err)
+	}
+
+	var mismatched struct {
+		Name nullable.Nullable[int] `msgpack:"name"`
+	}
+	if err := msgpack.Unmarshal(data, &mismatched); err == nil {
+		t.Errorf("msgpack.Unmarshal() into a mismatched type err = nil. Expected an error.")
+	}
+}
+
+func TestUnregistered(t *testing.T) {
+	// uint16 is never registered.
+	for _, n := range []nullable.Nullable[uint16]{nullable.From[uint16](5), nullable.Null[uint16](), nullable.Absent[uint16]()} {
+		data, err := msgpack.Marshal(n)
+		if err != nil || !reflect.DeepEqual(data, []byte{0x80}) {
+			t.Errorf("msgpack.Marshal(%+v) = %x, %v. Expected an empty map.", n, data, err)
+		}
+	}
+}
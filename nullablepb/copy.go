@@ -0,0 +1,348 @@
+package nullablepb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+/*
+nullableValue is implemented by any Nullable.
+*/
+type nullableValue interface {
+	IsPresent() bool
+	IsNull() bool
+}
+
+/*
+nullableField is implemented by a pointer to any Nullable.
+*/
+type nullableField interface {
+	IsPresent() bool
+	IsNull() bool
+	Clear()
+}
+
+var (
+	nullableFieldType = reflect.TypeOf((*nullableField)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+	timestampType     = reflect.TypeOf((*timestamppb.Timestamp)(nil))
+	durationpbType    = reflect.TypeOf((*durationpb.Duration)(nil))
+)
+
+/*
+wrapperTypes holds the pointer types of the wrapper messages, each of which holds a single Value field.
+*/
+var wrapperTypes = map[reflect.Type]bool{
+	reflect.TypeOf((*wrapperspb.StringValue)(nil)): true,
+	reflect.TypeOf((*wrapperspb.BytesValue)(nil)):  true,
+	reflect.TypeOf((*wrapperspb.BoolValue)(nil)):   true,
+	reflect.TypeOf((*wrapperspb.Int32Value)(nil)):  true,
+	reflect.TypeOf((*wrapperspb.Int64Value)(nil)):  true,
+	reflect.TypeOf((*wrapperspb.UInt32Value)(nil)): true,
+	reflect.TypeOf((*wrapperspb.UInt64Value)(nil)): true,
+	reflect.TypeOf((*wrapperspb.FloatValue)(nil)):  true,
+	reflect.TypeOf((*wrapperspb.DoubleValue)(nil)): true,
+}
+
+/*
+FromMessage copies the fields of msg, a generated message, into the fields of the struct pointed to by dst with the same Go name.
+Every Nullable field with a matching message field becomes present, and nil message fields make it null.
+Wrapper types, Timestamps, Durations and proto3 optional fields are unwrapped, nested messages are copied into structs or Nullables of structs, and integers and floats are converted to other sizes if they fit.
+Enums can also be copied into strings, which hold their names.
+Fields without a counterpart are left untouched, so combine FromMessage with nullable.FromFieldMask when only some fields should be present.
+
+	type User struct {
+		Name     nullable.Nullable[string]    `json:"name"`
+		Nickname nullable.Nullable[string]    `json:"nickname"` // *wrapperspb.StringValue
+		Age      nullable.Nullable[int]       `json:"age"`      // optional int32
+		Birthday nullable.Nullable[time.Time] `json:"birthday"` // *timestamppb.Timestamp
+	}
+
+	var user User
+	err := nullablepb.FromMessage(&user, response.User)
+*/
+func FromMessage(dst any, msg any) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return errors.New("FromMessage() called with a non-pointer or nil destination. Expected a pointer to a struct")
+	}
+	mv := reflect.ValueOf(msg)
+	if mv.Kind() != reflect.Pointer || mv.IsNil() || mv.Elem().Kind() != reflect.Struct {
+		return errors.New("FromMessage() called with a non-pointer or nil message")
+	}
+	return fromMessage(dv.Elem(), mv.Elem(), "")
+}
+
+func fromMessage(dv, mv reflect.Value, path string) error {
+	rt := dv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		mf, ok := messageField(mv, field.Name)
+		if !ok {
+			continue
+		}
+		fieldPath := joinPath(path, field.Name)
+
+		fv := dv.Field(i)
+		if elem, ok := nullableElem(fv.Type()); ok {
+			v, err := fromProto(mf, elem, fieldPath)
+			if err != nil {
+				return err
+			}
+			if !v.IsValid() {
+				fv.Addr().Interface().(nullableField).Clear()
+				continue
+			}
+			fv.Addr().MethodByName("Set").Call([]reflect.Value{v})
+			continue
+		}
+
+		v, err := fromProto(mf, fv.Type(), fieldPath)
+		if err != nil {
+			return err
+		}
+		if !v.IsValid() {
+			v = reflect.Zero(fv.Type())
+		}
+		fv.Set(v)
+	}
+	return nil
+}
+
+/*
+fromProto converts the message field mv to the type to.
+An invalid value is returned for nil pointers, which become null.
+*/
+func fromProto(mv reflect.Value, to reflect.Type, path string) (reflect.Value, error) {
+	if mv.Kind() == reflect.Pointer && mv.IsNil() {
+		return reflect.Value{}, nil
+	}
+	if mv.Type().AssignableTo(to) {
+		return mv, nil
+	}
+
+	if mv.Kind() == reflect.Pointer {
+		switch {
+		case wrapperTypes[mv.Type()]:
+			return fromProto(mv.Elem().FieldByName("Value"), to, path)
+		case mv.Type() == timestampType:
+			return fromProto(reflect.ValueOf(mv.Interface().(*timestamppb.Timestamp).AsTime()), to, path)
+		case mv.Type() == durationpbType:
+			return fromProto(reflect.ValueOf(mv.Interface().(*durationpb.Duration).AsDuration()), to, path)
+		case mv.Elem().Kind() == reflect.Struct:
+			target := to
+			if target.Kind() == reflect.Pointer {
+				target = target.Elem()
+			}
+			if target.Kind() != reflect.Struct {
+				break
+			}
+			nv := reflect.New(target)
+			if err := fromMessage(nv.Elem(), mv.Elem(), path); err != nil {
+				return reflect.Value{}, err
+			}
+			if to.Kind() == reflect.Pointer {
+				return nv, nil
+			}
+			return nv.Elem(), nil
+		default:
+			return fromProto(mv.Elem(), to, path)
+		}
+	}
+
+	if to.Kind() == reflect.String && mv.Kind() == reflect.Int32 {
+		if stringer, ok := mv.Interface().(fmt.Stringer); ok {
+			return reflect.ValueOf(stringer.String()).Convert(to), nil
+		}
+	}
+	return convertNumber(mv, to, path)
+}
+
+/*
+ToMessage copies the fields of the struct src into the fields of msg, a pointer to a generated message, with the same Go name.
+Absent Nullables leave their message field untouched and null Nullables clear it, while values are wrapped as needed by the message field's type.
+Nested structs and Nullables of structs are copied into newly allocated messages.
+
+	request := &pb.UpdateUserRequest{User: &pb.User{}}
+	err := nullablepb.ToMessage(request.User, user)
+*/
+func ToMessage(msg any, src any) error {
+	mv := reflect.ValueOf(msg)
+	if mv.Kind() != reflect.Pointer || mv.IsNil() || mv.Elem().Kind() != reflect.Struct {
+		return errors.New("ToMessage() called with a non-pointer or nil message")
+	}
+	sv := reflect.ValueOf(src)
+	for sv.Kind() == reflect.Pointer && !sv.IsNil() {
+		sv = sv.Elem()
+	}
+	if sv.Kind() != reflect.Struct {
+		return fmt.Errorf("ToMessage() called with a source of type %T. Expected a struct", src)
+	}
+	return toMessage(mv.Elem(), sv, "")
+}
+
+func toMessage(mv, sv reflect.Value, path string) error {
+	rt := sv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		mf, ok := messageField(mv, field.Name)
+		if !ok || !mf.CanSet() {
+			continue
+		}
+		fieldPath := joinPath(path, field.Name)
+
+		sf := sv.Field(i)
+		if _, ok := nullableElem(sf.Type()); ok {
+			n := sf.Interface().(nullableValue)
+			if !n.IsPresent() {
+				continue
+			}
+			if n.IsNull() {
+				mf.Set(reflect.Zero(mf.Type()))
+				continue
+			}
+			sf = sf.MethodByName("ValueOrDefault").Call(nil)[0]
+		}
+
+		v, err := toProto(sf, mf.Type(), fieldPath)
+		if err != nil {
+			return err
+		}
+		mf.Set(v)
+	}
+	return nil
+}
+
+/*
+toProto converts v to the message field type to.
+*/
+func toProto(v reflect.Value, to reflect.Type, path string) (reflect.Value, error) {
+	if v.Type().AssignableTo(to) {
+		return v, nil
+	}
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Zero(to), nil
+		}
+		return toProto(v.Elem(), to, path)
+	}
+
+	if to.Kind() == reflect.Pointer {
+		switch {
+		case wrapperTypes[to]:
+			w := reflect.New(to.Elem())
+			value := w.Elem().FieldByName("Value")
+			cv, err := toProto(v, value.Type(), path)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			value.Set(cv)
+			return w, nil
+		case to == timestampType && v.Type() == timeType:
+			return reflect.ValueOf(timestamppb.New(v.Interface().(time.Time))), nil
+		case to == durationpbType && v.Type() == durationType:
+			return reflect.ValueOf(durationpb.New(v.Interface().(time.Duration))), nil
+		case to.Elem().Kind() == reflect.Struct:
+			if v.Kind() != reflect.Struct || to == timestampType || to == durationpbType {
+				break
+			}
+			w := reflect.New(to.Elem())
+			if err := toMessage(w.Elem(), v, path); err != nil {
+				return reflect.Value{}, err
+			}
+			return w, nil
+		default:
+			cv, err := toProto(v, to.Elem(), path)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			p := reflect.New(to.Elem())
+			p.Elem().Set(cv)
+			return p, nil
+		}
+	}
+	return convertNumber(v, to, path)
+}
+
+/*
+convertNumber converts v to to if both are integers or both are floats and the value fits.
+*/
+func convertNumber(v reflect.Value, to reflect.Type, path string) (reflect.Value, error) {
+	fits := false
+	switch {
+	case isInt(v.Kind()) && isInt(to.Kind()):
+		fits = !reflect.Zero(to).OverflowInt(v.Int())
+	case isUint(v.Kind()) && isUint(to.Kind()):
+		fits = !reflect.Zero(to).OverflowUint(v.Uint())
+	case isFloat(v.Kind()) && isFloat(to.Kind()):
+		fits = !reflect.Zero(to).OverflowFloat(v.Float())
+	default:
+		return reflect.Value{}, fmt.Errorf("cannot convert %v to %v for %s", v.Type(), to, path)
+	}
+	if !fits {
+		return reflect.Value{}, fmt.Errorf("cannot convert %v to %v for %s: value out of range", v, to, path)
+	}
+	return v.Convert(to), nil
+}
+
+func isInt(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+func isUint(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uint64
+}
+
+func isFloat(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
+
+/*
+nullableElem returns the type held by the Nullable type typ, or false if typ isn't a Nullable.
+*/
+func nullableElem(typ reflect.Type) (reflect.Type, bool) {
+	ptr := reflect.PtrTo(typ)
+	if typ.Kind() != reflect.Struct || !ptr.Implements(nullableFieldType) {
+		return nil, false
+	}
+	set, ok := ptr.MethodByName("Set")
+	if !ok || set.Type.NumIn() != 2 {
+		return nil, false
+	}
+	return set.Type.In(1), true
+}
+
+/*
+messageField returns the exported field of the message struct mv named name.
+*/
+func messageField(mv reflect.Value, name string) (reflect.Value, bool) {
+	field, ok := mv.Type().FieldByName(name)
+	if !ok || !field.IsExported() {
+		return reflect.Value{}, false
+	}
+	fv, err := mv.FieldByIndexErr(field.Index)
+	if err != nil || !fv.CanInterface() {
+		return reflect.Value{}, false
+	}
+	return fv, true
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,169 @@
+package nullablepb
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/missingsemi/nullable"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+/*
+testAddress and testUser mirror the structs protoc-gen-go generates for proto3 messages.
+*/
+type testAddress struct {
+	City string
+	Zip  *string
+}
+
+type testUser struct {
+	state int
+
+	Name     string
+	Nickname *wrapperspb.StringValue
+	Age      *int32
+	Score    *wrapperspb.DoubleValue
+	Birthday *timestamppb.Timestamp
+	Timeout  *durationpb.Duration
+	Kind     descriptorpb.FieldDescriptorProto_Type
+	Tags     []string
+	Home     *testAddress
+	Work     *testAddress
+	Billing  *testAddress
+}
+
+type address struct {
+	City nullable.Nullable[string]
+	Zip  nullable.Nullable[string]
+}
+
+type user struct {
+	Name     nullable.Nullable[string]
+	Nickname nullable.Nullable[string]
+	Age      nullable.Nullable[int]
+	Score    nullable.Nullable[float64]
+	Birthday nullable.Nullable[time.Time]
+	Timeout  nullable.Nullable[time.Duration]
+	Kind     nullable.Nullable[string]
+	Tags     nullable.Nullable[[]string]
+	Home     address
+	Work     nullable.Nullable[address]
+	Billing  *address
+	Missing  nullable.Nullable[int]
+}
+
+func TestFromMessage(t *testing.T) {
+	birthday := time.Date(1990, 6, 1, 0, 0, 0, 0, time.UTC)
+	msg := &testUser{
+		Name:     "Ann",
+		Age:      proto.Int32(30),
+		Birthday: timestamppb.New(birthday),
+		Timeout:  durationpb.New(time.Minute),
+		Kind:     descriptorpb.FieldDescriptorProto_TYPE_STRING,
+		Home:     &testAddress{City: "Oslo", Zip: proto.String("0150")},
+		Work:     &testAddress{City: "Bergen"},
+	}
+
+	var got user
+	if err := FromMessage(&got, msg); err != nil {
+		t.Fatalf("FromMessage() err = %v. Expected nil.", err)
+	}
+	if got.Name.ValueOrDefault() != "Ann" || !got.Nickname.IsPresent() || !got.Nickname.IsNull() || got.Age.ValueOrDefault() != 30 {
+		t.Errorf("FromMessage() = %+v.", got)
+	}
+	if !got.Score.IsNull() || !got.Birthday.ValueOrDefault().Equal(birthday) || got.Timeout.ValueOrDefault() != time.Minute {
+		t.Errorf("FromMessage() = %+v.", got)
+	}
+	if got.Kind.ValueOrDefault() != "TYPE_STRING" || !got.Tags.IsPresent() || got.Missing.IsPresent() {
+		t.Errorf("FromMessage() = %+v.", got)
+	}
+	if got.Home.City.ValueOrDefault() != "Oslo" || got.Home.Zip.ValueOrDefault() != "0150" {
+		t.Errorf("FromMessage() Home = %+v.", got.Home)
+	}
+	if work := got.Work.ValueOrDefault(); work.City.ValueOrDefault() != "Bergen" || !work.Zip.IsNull() {
+		t.Errorf("FromMessage() Work = %+v.", work)
+	}
+	if got.Billing != nil {
+		t.Errorf("FromMessage() Billing = %+v. Expected nil.", got.Billing)
+	}
+
+	var mismatched struct{ Name nullable.Nullable[int] }
+	if err := FromMessage(&mismatched, msg); err == nil {
+		t.Errorf("FromMessage() with a mismatched type err = nil. Expected an error.")
+	}
+	var small struct{ Age nullable.Nullable[int8] }
+	if err := FromMessage(&small, &testUser{Age: proto.Int32(300)}); err == nil {
+		t.Errorf("FromMessage() with an overflowing value err = nil. Expected an error.")
+	}
+}
+
+func TestToMessage(t *testing.T) {
+	birthday := time.Date(1990, 6, 1, 0, 0, 0, 0, time.UTC)
+	src := user{
+		Name:     nullable.From("Ann"),
+		Nickname: nullable.From("annie"),
+		Age:      nullable.From(30),
+		Score:    nullable.Null[float64](),
+		Birthday: nullable.From(birthday),
+		Timeout:  nullable.From(time.Minute),
+		Tags:     nullable.From([]string{"a"}),
+		Home:     address{City: nullable.From("Oslo")},
+		Work:     nullable.From(address{Zip: nullable.From("5003")}),
+	}
+	msg := &testUser{Score: wrapperspb.Double(1), Kind: descriptorpb.FieldDescriptorProto_TYPE_BOOL}
+	if err := ToMessage(msg, &src); err != nil {
+		t.Fatalf("ToMessage() err = %v. Expected nil.", err)
+	}
+
+	expected := &testUser{
+		Name:     "Ann",
+		Nickname: wrapperspb.String("annie"),
+		Age:      proto.Int32(30),
+		Birthday: timestamppb.New(birthday),
+		Timeout:  durationpb.New(time.Minute),
+		Kind:     descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+		Tags:     []string{"a"},
+		Home:     &testAddress{City: "Oslo"},
+		Work:     &testAddress{Zip: proto.String("5003")},
+	}
+	if msg.Name != expected.Name || msg.Nickname.GetValue() != "annie" || *msg.Age != 30 || msg.Score != nil || msg.Kind != expected.Kind {
+		t.Errorf("ToMessage() = %+v. Expected %+v.", msg, expected)
+	}
+	if !msg.Birthday.AsTime().Equal(birthday) || msg.Timeout.AsDuration() != time.Minute || !reflect.DeepEqual(msg.Tags, expected.Tags) {
+		t.Errorf("ToMessage() = %+v. Expected %+v.", msg, expected)
+	}
+	if !reflect.DeepEqual(msg.Home, expected.Home) || !reflect.DeepEqual(msg.Work, expected.Work) || msg.Billing != nil {
+		t.Errorf("ToMessage() nested = %+v, %+v, %+v. Expected %+v, %+v, nil.", msg.Home, msg.Work, msg.Billing, expected.Home, expected.Work)
+	}
+}
+
+func TestMessageRoundTrip(t *testing.T) {
+	type field struct {
+		Name           nullable.Nullable[string]
+		Number         nullable.Nullable[int]
+		JsonName       nullable.Nullable[string]
+		Proto3Optional nullable.Nullable[bool]
+	}
+	src := field{Name: nullable.From("id"), Number: nullable.From(1), Proto3Optional: nullable.From(true)}
+
+	msg := &descriptorpb.FieldDescriptorProto{}
+	if err := ToMessage(msg, src); err != nil {
+		t.Fatalf("ToMessage() err = %v. Expected nil.", err)
+	}
+	if msg.GetName() != "id" || msg.GetNumber() != 1 || msg.JsonName != nil || !msg.GetProto3Optional() {
+		t.Errorf("ToMessage() = %v.", msg)
+	}
+
+	var got field
+	if err := FromMessage(&got, msg); err != nil {
+		t.Fatalf("FromMessage() err = %v. Expected nil.", err)
+	}
+	if got.Name.ValueOrDefault() != "id" || got.Number.ValueOrDefault() != 1 || !got.JsonName.IsNull() || !got.Proto3Optional.ValueOrDefault() {
+		t.Errorf("FromMessage(ToMessage()) = %+v. Expected %+v with a null JsonName.", got, src)
+	}
+}
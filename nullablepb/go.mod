@@ -0,0 +1,10 @@
+module github.com/missingsemi/nullable/nullablepb
+
+go 1.18
+
+require (
+	github.com/missingsemi/nullable v0.0.0
+	google.golang.org/protobuf v1.34.1
+)
+
+replace github.com/missingsemi/nullable => ../
@@ -0,0 +1,256 @@
+/*
+Package nullablepb converts Nullables to and from protocol buffer types.
+It supports the wrapper types, Timestamp and Duration, the pointers generated for proto3 optional fields, and copying between structs of Nullables and generated messages.
+A nil message or pointer becomes a null Nullable, and both null and absent Nullables become nil.
+*/
+package nullablepb
+
+import (
+	"time"
+
+	"github.com/missingsemi/nullable"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+/*
+Optional returns a pointer to the value held by n, as used by proto3 optional fields, or nil if n is null or absent.
+*/
+func Optional[T any](n nullable.Nullable[T]) *T {
+	if !n.HasValue() {
+		return nil
+	}
+	value := n.Value()
+	return &value
+}
+
+/*
+FromOptional returns a Nullable holding the value p points to, or a null Nullable if p is nil.
+*/
+func FromOptional[T any](p *T) nullable.Nullable[T] {
+	if p == nil {
+		return nullable.Null[T]()
+	}
+	return nullable.From(*p)
+}
+
+/*
+StringValue converts n to a *wrapperspb.StringValue.
+*/
+func StringValue(n nullable.Nullable[string]) *wrapperspb.StringValue {
+	if !n.HasValue() {
+		return nil
+	}
+	return wrapperspb.String(n.Value())
+}
+
+/*
+FromStringValue converts v to a Nullable[string].
+*/
+func FromStringValue(v *wrapperspb.StringValue) nullable.Nullable[string] {
+	if v == nil {
+		return nullable.Null[string]()
+	}
+	return nullable.From(v.GetValue())
+}
+
+/*
+BytesValue converts n to a *wrapperspb.BytesValue.
+*/
+func BytesValue(n nullable.Nullable[[]byte]) *wrapperspb.BytesValue {
+	if !n.HasValue() {
+		return nil
+	}
+	return wrapperspb.Bytes(n.Value())
+}
+
+/*
+FromBytesValue converts v to a Nullable[[]byte].
+*/
+func FromBytesValue(v *wrapperspb.BytesValue) nullable.Nullable[[]byte] {
+	if v == nil {
+		return nullable.Null[[]byte]()
+	}
+	return nullable.From(v.GetValue())
+}
+
+/*
+BoolValue converts n to a *wrapperspb.BoolValue.
+*/
+func BoolValue(n nullable.Nullable[bool]) *wrapperspb.BoolValue {
+	if !n.HasValue() {
+		return nil
+	}
+	return wrapperspb.Bool(n.Value())
+}
+
+/*
+FromBoolValue converts v to a Nullable[bool].
+*/
+func FromBoolValue(v *wrapperspb.BoolValue) nullable.Nullable[bool] {
+	if v == nil {
+		return nullable.Null[bool]()
+	}
+	return nullable.From(v.GetValue())
+}
+
+/*
+Int32Value converts n to a *wrapperspb.Int32Value.
+*/
+func Int32Value(n nullable.Nullable[int32]) *wrapperspb.Int32Value {
+	if !n.HasValue() {
+		return nil
+	}
+	return wrapperspb.Int32(n.Value())
+}
+
+/*
+FromInt32Value converts v to a Nullable[int32].
+*/
+func FromInt32Value(v *wrapperspb.Int32Value) nullable.Nullable[int32] {
+	if v == nil {
+		return nullable.Null[int32]()
+	}
+	return nullable.From(v.GetValue())
+}
+
+/*
+Int64Value converts n to a *wrapperspb.Int64Value.
+*/
+func Int64Value(n nullable.Nullable[int64]) *wrapperspb.Int64Value {
+	if !n.HasValue() {
+		return nil
+	}
+	return wrapperspb.Int64(n.Value())
+}
+
+/*
+FromInt64Value converts v to a Nullable[int64].
+*/
+func FromInt64Value(v *wrapperspb.Int64Value) nullable.Nullable[int64] {
+	if v == nil {
+		return nullable.Null[int64]()
+	}
+	return nullable.From(v.GetValue())
+}
+
+/*
+UInt32Value converts n to a *wrapperspb.UInt32Value.
+*/
+func UInt32Value(n nullable.Nullable[uint32]) *wrapperspb.UInt32Value {
+	if !n.HasValue() {
+		return nil
+	}
+	return wrapperspb.UInt32(n.Value())
+}
+
+/*
+FromUInt32Value converts v to a Nullable[uint32].
+*/
+func FromUInt32Value(v *wrapperspb.UInt32Value) nullable.Nullable[uint32] {
+	if v == nil {
+		return nullable.Null[uint32]()
+	}
+	return nullable.From(v.GetValue())
+}
+
+/*
+UInt64Value converts n to a *wrapperspb.UInt64Value.
+*/
+func UInt64Value(n nullable.Nullable[uint64]) *wrapperspb.UInt64Value {
+	if !n.HasValue() {
+		return nil
+	}
+	return wrapperspb.UInt64(n.Value())
+}
+
+/*
+FromUInt64Value converts v to a Nullable[uint64].
+*/
+func FromUInt64Value(v *wrapperspb.UInt64Value) nullable.Nullable[uint64] {
+	if v == nil {
+		return nullable.Null[uint64]()
+	}
+	return nullable.From(v.GetValue())
+}
+
+/*
+FloatValue converts n to a *wrapperspb.FloatValue.
+*/
+func FloatValue(n nullable.Nullable[float32]) *wrapperspb.FloatValue {
+	if !n.HasValue() {
+		return nil
+	}
+	return wrapperspb.Float(n.Value())
+}
+
+/*
+FromFloatValue converts v to a Nullable[float32].
+*/
+func FromFloatValue(v *wrapperspb.FloatValue) nullable.Nullable[float32] {
+	if v == nil {
+		return nullable.Null[float32]()
+	}
+	return nullable.From(v.GetValue())
+}
+
+/*
+DoubleValue converts n to a *wrapperspb.DoubleValue.
+*/
+func DoubleValue(n nullable.Nullable[float64]) *wrapperspb.DoubleValue {
+	if !n.HasValue() {
+		return nil
+	}
+	return wrapperspb.Double(n.Value())
+}
+
+/*
+FromDoubleValue converts v to a Nullable[float64].
+*/
+func FromDoubleValue(v *wrapperspb.DoubleValue) nullable.Nullable[float64] {
+	if v == nil {
+		return nullable.Null[float64]()
+	}
+	return nullable.From(v.GetValue())
+}
+
+/*
+Timestamp converts n to a *timestamppb.Timestamp.
+*/
+func Timestamp(n nullable.Nullable[time.Time]) *timestamppb.Timestamp {
+	if !n.HasValue() {
+		return nil
+	}
+	return timestamppb.New(n.Value())
+}
+
+/*
+FromTimestamp converts v to a Nullable[time.Time] in UTC.
+*/
+func FromTimestamp(v *timestamppb.Timestamp) nullable.Nullable[time.Time] {
+	if v == nil {
+		return nullable.Null[time.Time]()
+	}
+	return nullable.From(v.AsTime())
+}
+
+/*
+Duration converts n to a *durationpb.Duration.
+*/
+func Duration(n nullable.Nullable[time.Duration]) *durationpb.Duration {
+	if !n.HasValue() {
+		return nil
+	}
+	return durationpb.New(n.Value())
+}
+
+/*
+FromDuration converts v to a Nullable[time.Duration], saturating durations that don't fit.
+*/
+func FromDuration(v *durationpb.Duration) nullable.Nullable[time.Duration] {
+	if v == nil {
+		return nullable.Null[time.Duration]()
+	}
+	return nullable.From(v.AsDuration())
+}
@@ -0,0 +1,53 @@
+package nullablepb
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/missingsemi/nullable"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+/*
+checkRoundTrip converts a value, a null and an absent Nullable to a message and back.
+*/
+func checkRoundTrip[T any, M comparable](t *testing.T, value T, to func(nullable.Nullable[T]) M, from func(M) nullable.Nullable[T], equal func(T, T) bool) {
+	t.Helper()
+	var nilMessage M
+	if got := to(nullable.Null[T]()); got != nilMessage {
+		t.Errorf("converting a null %T = %v. Expected nil.", value, got)
+	}
+	if got := to(nullable.Absent[T]()); got != nilMessage {
+		t.Errorf("converting an absent %T = %v. Expected nil.", value, got)
+	}
+	if got := from(nilMessage); !got.IsPresent() || !got.IsNull() {
+		t.Errorf("converting a nil %T = %v. Expected null.", nilMessage, got)
+	}
+	if got := from(to(nullable.From(value))); !got.HasValue() || !equal(got.Value(), value) {
+		t.Errorf("round tripping %v = %v. Expected %v.", value, got.ValueOrDefault(), value)
+	}
+}
+
+func equal[T comparable](a, b T) bool {
+	return a == b
+}
+
+func TestWrappers(t *testing.T) {
+	checkRoundTrip(t, "hello", StringValue, FromStringValue, equal[string])
+	checkRoundTrip(t, []byte("hello"), BytesValue, FromBytesValue, bytes.Equal)
+	checkRoundTrip(t, true, BoolValue, FromBoolValue, equal[bool])
+	checkRoundTrip(t, int32(-32), Int32Value, FromInt32Value, equal[int32])
+	checkRoundTrip(t, int64(-64), Int64Value, FromInt64Value, equal[int64])
+	checkRoundTrip(t, uint32(32), UInt32Value, FromUInt32Value, equal[uint32])
+	checkRoundTrip(t, uint64(64), UInt64Value, FromUInt64Value, equal[uint64])
+	checkRoundTrip(t, float32(1.5), FloatValue, FromFloatValue, equal[float32])
+	checkRoundTrip(t, 2.5, DoubleValue, FromDoubleValue, equal[float64])
+	checkRoundTrip(t, time.Date(2022, 6, 1, 12, 0, 0, 123, time.UTC), Timestamp, FromTimestamp, time.Time.Equal)
+	checkRoundTrip(t, 90*time.Second, Duration, FromDuration, equal[time.Duration])
+	checkRoundTrip(t, 7, Optional[int], FromOptional[int], equal[int])
+
+	if got := StringValue(nullable.From("x")); got.GetValue() != "x" {
+		t.Errorf("StringValue(x) = %v. Expected %v.", got, wrapperspb.String("x"))
+	}
+}
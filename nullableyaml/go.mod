@@ -0,0 +1,10 @@
+module github.com/missingsemi/nullable/nullableyaml
+
+go 1.18
+
+require (
+	github.com/missingsemi/nullable v0.0.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+replace github.com/missingsemi/nullable => ../
@@ -0,0 +1,266 @@
+/*
+Package nullableyaml encodes Nullables with gopkg.in/yaml.v3.
+Null and absent Nullables are encoded as null and values with the encoder's own rules.
+Tag fields with omitempty to leave absent Nullables out of mappings.
+
+yaml.v3 never calls UnmarshalYAML for null nodes, so yaml.Unmarshal leaves the Nullable of a key holding null absent.
+Use Unmarshal to tell null keys apart from missing ones.
+*/
+package nullableyaml
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/missingsemi/nullable"
+	"gopkg.in/yaml.v3"
+)
+
+/*
+Nullable wraps a nullable.Nullable to implement the yaml.Marshaler and yaml.Unmarshaler interfaces.
+The methods of the wrapped Nullable are promoted, so it is used the same way.
+
+	type Config struct {
+		Replicas nullableyaml.Nullable[int]    `yaml:"replicas,omitempty"`
+		Image    nullableyaml.Nullable[string] `yaml:"image,omitempty"`
+	}
+*/
+type Nullable[T any] struct {
+	nullable.Nullable[T]
+}
+
+/*
+MarshalYAML implements the yaml.Marshaler interface.
+*/
+func (n Nullable[T]) MarshalYAML() (any, error) {
+	if n.IsNull() {
+		return nil, nil
+	}
+	return n.Value(), nil
+}
+
+/*
+UnmarshalYAML implements the yaml.Unmarshaler interface.
+Calls to UnmarshalYAML always mark the Nullable as present, and null nodes, including ~ and empty values, make it null.
+Keys missing from a mapping are never decoded, so they leave the Nullable absent.
+If the node can't be decoded, the Nullable is left null.
+*/
+func (n *Nullable[T]) UnmarshalYAML(value *yaml.Node) error {
+	n.Clear()
+	if isYAMLNull(value) {
+		return nil
+	}
+
+	var tmp T
+	if err := value.Decode(&tmp); err != nil {
+		return err
+	}
+	n.Set(tmp)
+	return nil
+}
+
+/*
+markNulls clears the Nullable if node is null, and otherwise marks the nulls of node within the value it holds.
+*/
+func (n *Nullable[T]) markNulls(node *yaml.Node) {
+	if isYAMLNull(node) {
+		n.Clear()
+		return
+	}
+	if n.IsNull() {
+		return
+	}
+	value := n.Value()
+	markNulls(node, reflect.ValueOf(&value).Elem())
+	n.Set(value)
+}
+
+/*
+nullMarker is implemented by a pointer to any Nullable of this package.
+*/
+type nullMarker interface {
+	markNulls(node *yaml.Node)
+}
+
+var yamlUnmarshalerType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+
+/*
+Unmarshal decodes data into v with yaml.Unmarshal and then marks every Nullable whose key holds null as present and null.
+
+	type Config struct {
+		Replicas nullableyaml.Nullable[int]    `yaml:"replicas"`
+		Image    nullableyaml.Nullable[string] `yaml:"image"`
+	}
+
+	var config Config
+	err := nullableyaml.Unmarshal([]byte("replicas: ~"), &config)
+	config.Replicas.IsNull()  // true
+	config.Image.IsAbsent()   // true
+
+Nullables are found in nested mappings, sequences and maps wherever the document holds them, following the same field names as yaml.v3.
+Null items of sequences of Nullables, which yaml.v3 skips, are put back at their index.
+Types with their own UnmarshalYAML method are left as they decoded themselves.
+*/
+func Unmarshal(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("Unmarshal() called with a non-pointer")
+	}
+	if err := yaml.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	markNulls(&doc, rv.Elem())
+	return nil
+}
+
+/*
+markNulls clears the Nullables of rv that node holds as null.
+rv must be addressable.
+*/
+func markNulls(node *yaml.Node, rv reflect.Value) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 1 {
+			markNulls(node.Content[0], rv)
+		}
+		return
+	case yaml.AliasNode:
+		markNulls(node.Alias, rv)
+		return
+	}
+
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return
+		}
+		rv = rv.Elem()
+	}
+	if n, ok := rv.Addr().Interface().(nullMarker); ok {
+		n.markNulls(node)
+		return
+	}
+	if reflect.PtrTo(rv.Type()).Implements(yamlUnmarshalerType) {
+		return
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		fields := yamlFields(rv.Type())
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			index, ok := fields[node.Content[i].Value]
+			if !ok {
+				continue
+			}
+			if fv, err := rv.FieldByIndexErr(index); err == nil {
+				markNulls(node.Content[i+1], fv)
+			}
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode || rv.IsNil() {
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := reflect.New(rv.Type().Key())
+			if err := node.Content[i].Decode(key.Interface()); err != nil {
+				continue
+			}
+			elem := rv.MapIndex(key.Elem())
+			if !elem.IsValid() {
+				continue
+			}
+			// Copy the element so that it is addressable.
+			tmp := reflect.New(elem.Type()).Elem()
+			tmp.Set(elem)
+			markNulls(node.Content[i+1], tmp)
+			rv.SetMapIndex(key.Elem(), tmp)
+		}
+	case reflect.Slice, reflect.Array:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+		if _, ok := reflect.New(rv.Type().Elem()).Interface().(nullMarker); ok {
+			spreadNulls(node, rv)
+		}
+		for i := 0; i < len(node.Content) && i < rv.Len(); i++ {
+			markNulls(node.Content[i], rv.Index(i))
+		}
+	}
+}
+
+/*
+spreadNulls moves the Nullables decoded from the items of node back to the index of their item.
+yaml.v3 skips null items when it decodes a sequence into Nullables, which shifts the items after them.
+*/
+func spreadNulls(node *yaml.Node, rv reflect.Value) {
+	decoded := reflect.MakeSlice(reflect.SliceOf(rv.Type().Elem()), rv.Len(), rv.Len())
+	reflect.Copy(decoded, rv)
+	if rv.Kind() == reflect.Slice && rv.Len() < len(node.Content) {
+		rv.Set(reflect.MakeSlice(rv.Type(), len(node.Content), len(node.Content)))
+	}
+
+	j := 0
+	for i := 0; i < len(node.Content) && i < rv.Len(); i++ {
+		if isYAMLNull(node.Content[i]) {
+			rv.Index(i).Set(reflect.Zero(rv.Type().Elem()))
+		} else if j < decoded.Len() {
+			rv.Index(i).Set(decoded.Index(j))
+			j++
+		}
+	}
+}
+
+/*
+isYAMLNull reports whether node is a null scalar.
+*/
+func isYAMLNull(node *yaml.Node) bool {
+	if node.Kind == yaml.AliasNode {
+		return isYAMLNull(node.Alias)
+	}
+	return node.Kind == 0 || node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null"
+}
+
+/*
+yamlFields returns the index of every field of the struct type typ under its yaml key.
+Keys default to the lowercased field name, and fields of inlined structs are promoted.
+*/
+func yamlFields(typ reflect.Type) map[string][]int {
+	fields := make(map[string][]int)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("yaml")
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if strings.Contains(","+options+",", ",inline,") {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() != reflect.Struct {
+				continue
+			}
+			for key, index := range yamlFields(ft) {
+				if _, ok := fields[key]; !ok {
+					fields[key] = append([]int{i}, index...)
+				}
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = []int{i}
+	}
+	return fields
+}
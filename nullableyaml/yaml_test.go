@@ -0,0 +1,133 @@
+package nullableyaml
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/missingsemi/nullable"
+	"gopkg.in/yaml.v3"
+)
+
+type testProbe struct {
+	Path   Nullable[string] `yaml:"path"`
+	Period Nullable[int]    `yaml:"period,omitempty"`
+}
+
+type Meta struct {
+	Owner Nullable[string] `yaml:"owner,omitempty"`
+}
+
+type testConfig struct {
+	Meta     `yaml:",inline"`
+	Replicas Nullable[int]                   `yaml:"replicas,omitempty"`
+	Image    Nullable[string]                `yaml:"image,omitempty"`
+	Labels   Nullable[map[string]string]     `yaml:"labels,omitempty"`
+	Probe    Nullable[testProbe]             `yaml:"probe,omitempty"`
+	Ports    []Nullable[int]                 `yaml:"ports,omitempty"`
+	Limits   map[string]Nullable[string]     `yaml:"limits,omitempty"`
+	Env      *struct{ Debug Nullable[bool] } `yaml:"env,omitempty"`
+}
+
+func TestMarshalYAML(t *testing.T) {
+	config := testConfig{
+		Meta:     Meta{Owner: Nullable[string]{nullable.Null[string]()}},
+		Replicas: Nullable[int]{nullable.From(3)},
+		Probe:    Nullable[testProbe]{nullable.From(testProbe{Path: Nullable[string]{nullable.From("/health")}})},
+		Ports:    []Nullable[int]{{nullable.From(80)}, {nullable.Null[int]()}},
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() err = %v. Expected nil.", err)
+	}
+	expected := "owner: null\nreplicas: 3\nprobe:\n    path: /health\nports:\n    - 80\n    - null\n"
+	if string(data) != expected {
+		t.Errorf("yaml.Marshal() = %q. Expected %q.", data, expected)
+	}
+}
+
+func TestUnmarshalYAMLMethod(t *testing.T) {
+	var config testConfig
+	data := "replicas: ~\nimage: nginx\nprobe: {path: /health}\n"
+	if err := yaml.Unmarshal([]byte(data), &config); err != nil {
+		t.Fatalf("yaml.Unmarshal() err = %v. Expected nil.", err)
+	}
+	// yaml.v3 skips UnmarshalYAML for null nodes.
+	if config.Replicas.IsPresent() || config.Image.ValueOrDefault() != "nginx" || config.Labels.IsPresent() {
+		t.Errorf("yaml.Unmarshal() = %+v.", config)
+	}
+	if probe := config.Probe.ValueOrDefault(); probe.Path.ValueOrDefault() != "/health" || probe.Period.IsPresent() {
+		t.Errorf("yaml.Unmarshal() Probe = %+v.", config.Probe)
+	}
+
+	for _, text := range []string{"null", "~", "", "!!null x"} {
+		var node yaml.Node
+		if err := yaml.Unmarshal([]byte("key: "+text), &node); err != nil {
+			t.Fatalf("yaml.Unmarshal(%q) err = %v. Expected nil.", text, err)
+		}
+		n := Nullable[int]{nullable.From(1)}
+		if err := n.UnmarshalYAML(node.Content[0].Content[1]); err != nil || !n.IsPresent() || !n.IsNull() {
+			t.Errorf("n.UnmarshalYAML(%q) = %+v, %v. Expected a present null.", text, n, err)
+		}
+	}
+
+	var mismatched struct {
+		Image Nullable[int] `yaml:"image"`
+	}
+	if err := yaml.Unmarshal([]byte(data), &mismatched); err == nil {
+		t.Errorf("yaml.Unmarshal() into a mismatched type err = nil. Expected an error.")
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	data := `
+owner: null
+replicas: ~
+image:
+labels: {app: web}
+probe:
+  path: /health
+  period: null
+ports: [80, null, &p 443, *p]
+limits:
+  cpu: ~
+  memory: 1Gi
+env:
+  debug: null
+`
+	var config testConfig
+	if err := Unmarshal([]byte(data), &config); err != nil {
+		t.Fatalf("Unmarshal() err = %v. Expected nil.", err)
+	}
+	for name, n := range map[string]Nullable[string]{"owner": config.Owner, "image": config.Image, "limits.cpu": config.Limits["cpu"]} {
+		if !n.IsPresent() || !n.IsNull() {
+			t.Errorf("Unmarshal() %s = %+v. Expected a present null.", name, n)
+		}
+	}
+	if !config.Replicas.IsPresent() || !config.Replicas.IsNull() {
+		t.Errorf("Unmarshal() replicas = %+v. Expected a present null.", config.Replicas)
+	}
+	if !reflect.DeepEqual(config.Labels.ValueOrDefault(), map[string]string{"app": "web"}) || config.Limits["memory"].ValueOrDefault() != "1Gi" {
+		t.Errorf("Unmarshal() labels = %+v, limits = %+v.", config.Labels, config.Limits)
+	}
+	if probe := config.Probe.ValueOrDefault(); probe.Path.ValueOrDefault() != "/health" || !probe.Period.IsPresent() || !probe.Period.IsNull() {
+		t.Errorf("Unmarshal() probe = %+v.", probe)
+	}
+	expected := []Nullable[int]{{nullable.From(80)}, {nullable.Null[int]()}, {nullable.From(443)}, {nullable.From(443)}}
+	if !reflect.DeepEqual(config.Ports, expected) {
+		t.Errorf("Unmarshal() ports = %+v. Expected %+v.", config.Ports, expected)
+	}
+	if config.Env == nil || !config.Env.Debug.IsPresent() || !config.Env.Debug.IsNull() {
+		t.Errorf("Unmarshal() env = %+v. Expected a present null.", config.Env)
+	}
+
+	var missing testConfig
+	if err := Unmarshal([]byte("{}"), &missing); err != nil || !reflect.DeepEqual(missing, testConfig{}) {
+		t.Errorf("Unmarshal({}) = %+v, %v. Expected absent fields.", missing, err)
+	}
+	if err := Unmarshal([]byte("replicas: x"), &missing); err == nil {
+		t.Errorf("Unmarshal() with an invalid value err = nil. Expected an error.")
+	}
+	if err := Unmarshal([]byte("{}"), missing); err == nil {
+		t.Errorf("Unmarshal() with a non-pointer err = nil. Expected an error.")
+	}
+}
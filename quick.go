@@ -0,0 +1,78 @@
+package nullable
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing/quick"
+)
+
+/*
+Weights controls how often each state is produced when generating arbitrary Nullables.
+Each weight is relative to the sum of all three, so Weights{1, 1, 2} produces a value half of the time.
+*/
+type Weights struct {
+	Absent int
+	Null   int
+	Value  int
+}
+
+/*
+DefaultWeights are the weights used by Generate and by Arbitrary when it is given zero Weights.
+*/
+var DefaultWeights = Weights{Absent: 1, Null: 1, Value: 2}
+
+/*
+Arbitrary creates a random Nullable that is either absent, null, or holds a value, chosen according to weights.
+Values are produced by testing/quick, so a T implementing quick.Generator controls how its own values are generated.
+If all weights are zero, DefaultWeights are used.
+Arbitrary panics if a weight is negative or if testing/quick cannot generate values of type T.
+*/
+func Arbitrary[T any](rand *rand.Rand, weights Weights) Nullable[T] {
+	return arbitrary[T](rand, weights, defaultSize)
+}
+
+/*
+defaultSize matches the size testing/quick passes to quick.Generator when it generates values on its own.
+*/
+const defaultSize = 50
+
+/*
+arbitrary implements Arbitrary, passing size to the generator of T if it implements quick.Generator.
+*/
+func arbitrary[T any](rand *rand.Rand, weights Weights, size int) Nullable[T] {
+	if weights.Absent < 0 || weights.Null < 0 || weights.Value < 0 {
+		panic(fmt.Sprintf("Arbitrary() called with negative weights %+v", weights))
+	}
+	if weights == (Weights{}) {
+		weights = DefaultWeights
+	}
+
+	pick := rand.Intn(weights.Absent + weights.Null + weights.Value)
+	if pick < weights.Absent {
+		return Absent[T]()
+	}
+	if pick < weights.Absent+weights.Null {
+		return Null[T]()
+	}
+
+	var zero T
+	if gen, ok := any(zero).(quick.Generator); ok {
+		return From(gen.Generate(rand, size).Interface().(T))
+	}
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	val, ok := quick.Value(typ, rand)
+	if !ok {
+		panic(fmt.Sprintf("Arbitrary() called with ungeneratable type %v", typ))
+	}
+	return From(val.Interface().(T))
+}
+
+/*
+Generate implements the quick.Generator interface using DefaultWeights.
+This allows structs with Nullable fields to be used with quick.Check and quick.Value.
+If T implements quick.Generator, size is passed on to it.
+*/
+func (n Nullable[T]) Generate(rand *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(arbitrary[T](rand, DefaultWeights, size))
+}
@@ -0,0 +1,131 @@
+package nullable
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+type quickCelsius float64
+
+func (quickCelsius) Generate(rand *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(quickCelsius(-273.15))
+}
+
+type quickSize int
+
+func (quickSize) Generate(rand *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(quickSize(size))
+}
+
+func TestArbitrary(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	{
+		for i := 0; i < 100; i++ {
+			got := Arbitrary[int](r, Weights{Absent: 1})
+			if got.present == true {
+				t.Fatal("got.IsPresent() = true. Expected false.")
+			}
+		}
+	}
+	{
+		for i := 0; i < 100; i++ {
+			got := Arbitrary[int](r, Weights{Null: 1})
+			if got.present == false || got.ptr != nil {
+				t.Fatalf("got = %+v. Expected present null.", got)
+			}
+		}
+	}
+	{
+		for i := 0; i < 100; i++ {
+			got := Arbitrary[quickCelsius](r, Weights{Value: 1})
+			if got.present == false || got.ptr == nil {
+				t.Fatalf("got = %+v. Expected present value.", got)
+			} else if *got.ptr != -273.15 {
+				t.Fatalf("got.Value() = %v. Expected %v.", *got.ptr, -273.15)
+			}
+		}
+	}
+	{
+		var absent, null, value int
+		for i := 0; i < 1000; i++ {
+			got := Arbitrary[string](r, Weights{})
+			switch {
+			case !got.present:
+				absent++
+			case got.ptr == nil:
+				null++
+			default:
+				value++
+			}
+		}
+		if absent == 0 || null == 0 || value == 0 {
+			t.Errorf("absent, null, value = %v, %v, %v. Expected all states.", absent, null, value)
+		}
+	}
+	{
+		defer func() {
+			if recover() == nil {
+				t.Error("Arbitrary[int](r, Weights{Absent: -1}) did not panic.")
+			}
+		}()
+		Arbitrary[int](r, Weights{Absent: -1})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	{
+		type S struct {
+			A Nullable[int]
+			B Nullable[string]
+		}
+		roundTrip := func(s S) bool {
+			j, err := json.Marshal(s)
+			if err != nil {
+				return false
+			}
+			var got S
+			if err := json.Unmarshal(j, &got); err != nil {
+				return false
+			}
+			return got.A.HasValue() == s.A.HasValue() && got.A.ValueOrDefault() == s.A.ValueOrDefault() &&
+				got.B.HasValue() == s.B.HasValue() && got.B.ValueOrDefault() == s.B.ValueOrDefault()
+		}
+		if err := quick.Check(roundTrip, nil); err != nil {
+			t.Errorf("quick.Check(roundTrip, nil) = %v. Expected %v.", err, nil)
+		}
+	}
+	{
+		var seen [3]bool
+		r := rand.New(rand.NewSource(2))
+		for i := 0; i < 100; i++ {
+			v, ok := quick.Value(reflect.TypeOf(Nullable[int]{}), r)
+			if !ok {
+				t.Fatal("quick.Value(Nullable[int]) ok = false. Expected true.")
+			}
+			got := v.Interface().(Nullable[int])
+			switch {
+			case !got.present:
+				seen[0] = true
+			case got.ptr == nil:
+				seen[1] = true
+			default:
+				seen[2] = true
+			}
+		}
+		if seen != [3]bool{true, true, true} {
+			t.Errorf("seen = %v. Expected all states.", seen)
+		}
+	}
+	{
+		r := rand.New(rand.NewSource(3))
+		for i := 0; i < 100; i++ {
+			got := Nullable[quickSize]{}.Generate(r, 7).Interface().(Nullable[quickSize])
+			if got.ptr != nil && *got.ptr != 7 {
+				t.Fatalf("Generate(r, 7).Value() = %v. Expected %v.", *got.ptr, 7)
+			}
+		}
+	}
+}
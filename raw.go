@@ -0,0 +1,152 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+/*
+RawNullable holds the undecoded JSON of a value that can be absent, null or present.
+It is useful for proxies and webhook forwarders that need to know whether a key was set without paying to decode its value.
+
+	type Event struct {
+		Payload nullable.RawNullable `json:"payload"`
+	}
+
+	var event Event
+	json.Unmarshal([]byte(`{"payload": {"id": 7}}`), &event)
+	event.Payload.IsPresent() // true
+	event.Payload.Raw()       // {"id": 7}
+
+	payload, err := nullable.DecodeRaw[Payload](event.Payload)
+
+The bytes are kept exactly as they were received and MarshalJSON returns them unchanged.
+Note that encoding/json compacts the output of MarshalJSON when a RawNullable is marshaled as part of another value.
+*/
+type RawNullable struct {
+	raw     json.RawMessage
+	present bool
+}
+
+/*
+RawFrom returns a present RawNullable holding raw, which must be valid JSON.
+An empty raw or the JSON null literal produces a null RawNullable.
+*/
+func RawFrom(raw json.RawMessage) RawNullable {
+	if isJSONNull(raw) {
+		return RawNullable{present: true}
+	}
+	return RawNullable{raw: raw, present: true}
+}
+
+/*
+IsNull returns true if the RawNullable is null, false otherwise.
+*/
+func (r RawNullable) IsNull() bool {
+	return r.raw == nil
+}
+
+/*
+HasValue returns true if the RawNullable holds a value, false otherwise.
+*/
+func (r RawNullable) HasValue() bool {
+	return r.raw != nil
+}
+
+/*
+IsPresent returns true if the RawNullable holds a value or was explicitly set to null.
+*/
+func (r RawNullable) IsPresent() bool {
+	return r.present
+}
+
+/*
+IsAbsent returns true if the RawNullable neither holds a value nor was explicitly set to null.
+*/
+func (r RawNullable) IsAbsent() bool {
+	return !r.present
+}
+
+/*
+IsZero returns true if the RawNullable is absent.
+Like Nullable.IsZero, it lets encoders that support omitting zero values omit absent RawNullables while keeping nulls.
+*/
+func (r RawNullable) IsZero() bool {
+	return !r.present
+}
+
+/*
+Raw returns the JSON held by the RawNullable, or nil if it is null.
+The returned bytes must not be modified.
+*/
+func (r RawNullable) Raw() json.RawMessage {
+	return r.raw
+}
+
+/*
+Clear sets the RawNullable to null and marks it as present.
+*/
+func (r *RawNullable) Clear() {
+	r.raw = nil
+	r.present = true
+}
+
+/*
+UnmarshalJSON implements the json.Unmarshaler interface.
+It copies raw without decoding it, and always marks the RawNullable as present.
+*/
+func (r *RawNullable) UnmarshalJSON(raw []byte) error {
+	r.present = true
+	if isJSONNull(raw) {
+		r.raw = nil
+		return nil
+	}
+	r.raw = append(r.raw[:0:0], raw...)
+	return nil
+}
+
+/*
+MarshalJSON implements the json.Marshaler interface.
+It returns the held JSON unchanged, or null if the RawNullable is null or absent.
+*/
+func (r RawNullable) MarshalJSON() ([]byte, error) {
+	if r.raw == nil {
+		return []byte("null"), nil
+	}
+	return r.raw, nil
+}
+
+/*
+DecodeRaw decodes the JSON held by r into a Nullable[T].
+Absent and null RawNullables produce absent and null Nullables, and decoding errors are the same as those of Nullable.UnmarshalJSON.
+*/
+func DecodeRaw[T any](r RawNullable) (Nullable[T], error) {
+	var n Nullable[T]
+	if !r.present {
+		return n, nil
+	}
+	if r.raw == nil {
+		return Null[T](), nil
+	}
+	err := n.UnmarshalJSON(r.raw)
+	return n, err
+}
+
+/*
+toInterfaceNullable implements interfaceable for the RawNullable type.
+*/
+func (r RawNullable) toInterfaceNullable() interfaceNullable {
+	if r.raw == nil {
+		return interfaceNullable{present: r.present}
+	}
+	tmp := interface{}(r.raw)
+	return interfaceNullable{ptr: &tmp, present: r.present}
+}
+
+/*
+isJSONNull reports whether raw is empty or the JSON null literal, ignoring surrounding whitespace.
+*/
+func isJSONNull(raw []byte) bool {
+	raw = bytes.TrimSpace(raw)
+	return len(raw) == 0 || string(raw) == "null"
+}
@@ -0,0 +1,144 @@
+package nullable
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestRawNullableUnmarshalJSON(t *testing.T) {
+	type Event struct {
+		Payload RawNullable `json:"payload"`
+	}
+	{
+		var event Event
+		if err := json.Unmarshal([]byte(`{}`), &event); err != nil {
+			t.Fatalf("json.Unmarshal(j, &event) = %v. Expected %v.", err, nil)
+		}
+		if event.Payload.IsPresent() || !event.Payload.IsNull() || event.Payload.Raw() != nil {
+			t.Errorf("event.Payload = %+v. Expected absent.", event.Payload)
+		}
+	}
+	{
+		var event Event
+		if err := json.Unmarshal([]byte(`{"payload": null}`), &event); err != nil {
+			t.Fatalf("json.Unmarshal(j, &event) = %v. Expected %v.", err, nil)
+		}
+		if !event.Payload.IsPresent() || !event.Payload.IsNull() || event.Payload.HasValue() || event.Payload.Raw() != nil {
+			t.Errorf("event.Payload = %+v. Expected present null.", event.Payload)
+		}
+	}
+	{
+		var event Event
+		if err := json.Unmarshal([]byte(`{"payload": {"id": 7,  "tags": ["a"]}}`), &event); err != nil {
+			t.Fatalf("json.Unmarshal(j, &event) = %v. Expected %v.", err, nil)
+		}
+		if !event.Payload.IsPresent() || !event.Payload.HasValue() {
+			t.Errorf("event.Payload = %+v. Expected a present value.", event.Payload)
+		}
+		if got := string(event.Payload.Raw()); got != `{"id": 7,  "tags": ["a"]}` {
+			t.Errorf("event.Payload.Raw() = %s. Expected %s.", got, `{"id": 7,  "tags": ["a"]}`)
+		}
+	}
+	{
+		var event Event
+		if err := json.Unmarshal([]byte(`{"payload": "null"}`), &event); err != nil {
+			t.Fatalf("json.Unmarshal(j, &event) = %v. Expected %v.", err, nil)
+		}
+		if got := string(event.Payload.Raw()); event.Payload.IsNull() || got != `"null"` {
+			t.Errorf("event.Payload.Raw() = %s. Expected %s.", got, `"null"`)
+		}
+	}
+	{
+		var event Event
+		if err := json.Unmarshal([]byte(`{"payload": 1.50}`), &event); err != nil {
+			t.Fatalf("json.Unmarshal(j, &event) = %v. Expected %v.", err, nil)
+		}
+		if got := string(event.Payload.Raw()); event.Payload.IsNull() || got != `1.50` {
+			t.Errorf("event.Payload.Raw() = %s. Expected %s.", got, `1.50`)
+		}
+	}
+}
+
+func TestRawNullableMarshalJSON(t *testing.T) {
+	{
+		got, err := RawNullable{}.MarshalJSON()
+		if err != nil || string(got) != "null" {
+			t.Errorf("RawNullable{}.MarshalJSON() = %s, %v. Expected %s, %v.", got, err, "null", nil)
+		}
+	}
+	{
+		got, err := RawFrom(nil).MarshalJSON()
+		if err != nil || string(got) != "null" {
+			t.Errorf("RawFrom(nil).MarshalJSON() = %s, %v. Expected %s, %v.", got, err, "null", nil)
+		}
+	}
+	{
+		r := RawFrom(json.RawMessage{})
+		got, err := r.MarshalJSON()
+		if !r.IsPresent() || !r.IsNull() || err != nil || string(got) != "null" {
+			t.Errorf("RawFrom(\"\").MarshalJSON() = %s, %v. Expected %s, %v.", got, err, "null", nil)
+		}
+	}
+	{
+		got, err := RawFrom(json.RawMessage(" null ")).MarshalJSON()
+		if err != nil || string(got) != "null" {
+			t.Errorf("RawFrom(\" null \").MarshalJSON() = %s, %v. Expected %s, %v.", got, err, "null", nil)
+		}
+	}
+	{
+		got, err := RawFrom(json.RawMessage(`{"b": 1,  "a": 1.50}`)).MarshalJSON()
+		if err != nil || string(got) != `{"b": 1,  "a": 1.50}` {
+			t.Errorf("MarshalJSON() = %s, %v. Expected %s, %v.", got, err, `{"b": 1,  "a": 1.50}`, nil)
+		}
+	}
+	{
+		var r RawNullable
+		input := []byte(`[1,  2]`)
+		r.UnmarshalJSON(input)
+		input[1] = '9'
+		if got, _ := r.MarshalJSON(); string(got) != `[1,  2]` {
+			t.Errorf("MarshalJSON() after modifying the input = %s. Expected %s.", got, `[1,  2]`)
+		}
+	}
+}
+
+func TestDecodeRaw(t *testing.T) {
+	n, err := DecodeRaw[int](RawNullable{})
+	if err != nil || n.IsPresent() {
+		t.Errorf("DecodeRaw[int](absent) = %v, %v. Expected absent, nil.", n, err)
+	}
+
+	n, err = DecodeRaw[int](RawFrom(nil))
+	if err != nil || !n.IsPresent() || !n.IsNull() {
+		t.Errorf("DecodeRaw[int](null) = %v, %v. Expected null, nil.", n, err)
+	}
+
+	n, err = DecodeRaw[int](RawFrom(json.RawMessage("42")))
+	if err != nil || n.ValueOrDefault() != 42 {
+		t.Errorf("DecodeRaw[int](42) = %v, %v. Expected 42, nil.", n, err)
+	}
+
+	_, err = DecodeRaw[int](RawFrom(json.RawMessage(`"x"`)))
+	var unmarshalErr *UnmarshalError
+	if !errors.As(err, &unmarshalErr) {
+		t.Errorf("DecodeRaw[int](\"x\") err = %v. Expected an *UnmarshalError.", err)
+	}
+}
+
+func TestRawNullableMerge(t *testing.T) {
+	type Config struct {
+		Extra RawNullable
+	}
+	var got Config
+	_, err := Merge(&got, Config{Extra: RawFrom(json.RawMessage("1"))}, Config{})
+	if err != nil || string(got.Extra.Raw()) != "1" {
+		t.Errorf("Merge() Extra = %s, %v. Expected 1, nil.", got.Extra.Raw(), err)
+	}
+}
+
+func TestRawNullableIsZero(t *testing.T) {
+	if !(RawNullable{}).IsZero() || RawFrom(nil).IsZero() || RawFrom(json.RawMessage("0")).IsZero() {
+		t.Errorf("IsZero() = %v, %v, %v. Expected true, false, false.", RawNullable{}.IsZero(), RawFrom(nil).IsZero(), RawFrom(json.RawMessage("0")).IsZero())
+	}
+}
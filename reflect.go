@@ -0,0 +1,70 @@
+package nullable
+
+import "reflect"
+
+/*
+reflector is implemented by a pointer to any Nullable.
+It lets struct walkers read and write Nullables whose type parameter is only known at runtime.
+*/
+type reflector interface {
+	presenter
+	IsNull() bool
+	Clear()
+	elemType() reflect.Type
+	reflectValue() reflect.Value
+	setReflect(v reflect.Value)
+}
+
+var reflectorType = reflect.TypeOf((*reflector)(nil)).Elem()
+
+/*
+asReflector returns the reflector for v if v is an addressable Nullable.
+*/
+func asReflector(v reflect.Value) (reflector, bool) {
+	if !v.CanAddr() {
+		return nil, false
+	}
+	r, ok := v.Addr().Interface().(reflector)
+	return r, ok
+}
+
+/*
+addressable returns v if it is addressable, and otherwise an addressable copy of v.
+Walkers use it on structs passed by value, whose Nullable fields are only reachable through pointer methods once they are addressable.
+*/
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	tmp := reflect.New(v.Type()).Elem()
+	tmp.Set(v)
+	return tmp
+}
+
+/*
+elemType implements reflector for the Nullable type.
+*/
+func (n *Nullable[T]) elemType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+/*
+reflectValue implements reflector for the Nullable type.
+The returned value is addressable, or invalid if the Nullable is null.
+*/
+func (n *Nullable[T]) reflectValue() reflect.Value {
+	if n.ptr == nil {
+		return reflect.Value{}
+	}
+	return reflect.ValueOf(n.ptr).Elem()
+}
+
+/*
+setReflect implements reflector for the Nullable type.
+v must be assignable to T.
+*/
+func (n *Nullable[T]) setReflect(v reflect.Value) {
+	var tmp T
+	reflect.ValueOf(&tmp).Elem().Set(v)
+	n.Set(tmp)
+}
@@ -0,0 +1,394 @@
+package nullable
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+/*
+Placeholder is a style of SQL bind parameter.
+*/
+type Placeholder int
+
+const (
+	// Question writes ? for every parameter, as used by MySQL and SQLite.
+	Question Placeholder = iota
+	// Dollar writes numbered $1, $2, ... parameters, as used by Postgres.
+	Dollar
+)
+
+/*
+Dialect selects the SQL features used when building statements.
+*/
+type Dialect int
+
+const (
+	// Generic only uses features shared by Postgres, MySQL and SQLite.
+	Generic Dialect = iota
+	// Postgres allows DEFAULT in VALUES and RETURNING clauses, and always uses Dollar placeholders.
+	Postgres
+	// MySQL allows DEFAULT in VALUES but not RETURNING clauses.
+	MySQL
+	// SQLite allows RETURNING clauses but not DEFAULT in VALUES.
+	SQLite
+)
+
+/*
+Statement is a built SQL statement and its parameters.
+*/
+type Statement struct {
+	Query string
+	Args  []any
+}
+
+/*
+ErrNoColumns is returned when building a statement from a struct without any present Nullable fields.
+*/
+var ErrNoColumns = errors.New("no present Nullable fields with a db tag")
+
+/*
+SQL holds the options used to build SQL statements from structs of Nullable fields.
+The zero value writes ? placeholders and generic SQL, which suits MySQL and SQLite.
+*/
+type SQL struct {
+	Placeholders Placeholder
+	Dialect      Dialect
+}
+
+/*
+BuildUpdate builds an UPDATE statement using a zero SQL.
+*/
+func BuildUpdate(table string, v any, where string, args ...any) (string, []any, error) {
+	return SQL{}.Update(table, v, where, args...)
+}
+
+/*
+Update builds an UPDATE statement for table that sets the columns named by the db tags of the present Nullable fields of the struct v.
+Fields holding values are passed as parameters, null fields set their column to NULL, and absent fields are left out.
+Nested structs and pointers to structs are walked recursively, and fields that aren't Nullable are ignored.
+
+where is added as the WHERE clause, written with ? placeholders for args whatever the placeholder style, and an empty where updates every row.
+Placeholders are rewritten to the configured style and numbered after those of the SET clause, skipping quoted strings and identifiers.
+
+	type UserPatch struct {
+		Name  nullable.Nullable[string] `db:"name"`
+		Email nullable.Nullable[string] `db:"email"`
+		Age   nullable.Nullable[int]    `db:"age"`
+	}
+
+	patch := UserPatch{Name: nullable.From("Ann"), Email: nullable.Null[string]()}
+	query, args, err := nullable.SQL{Placeholders: nullable.Dollar}.Update("users", patch, "id = ?", 7)
+	// UPDATE users SET name = $1, email = NULL WHERE id = $2
+	// [Ann 7]
+	db.ExecContext(ctx, query, args...)
+
+If no field is present, ErrNoColumns is returned since the statement would be invalid.
+*/
+func (s SQL) Update(table string, v any, where string, args ...any) (string, []any, error) {
+	columns, err := sqlColumns(v, "SQL.Update()")
+	if err != nil {
+		return "", nil, err
+	}
+	if len(columns) == 0 {
+		return "", nil, ErrNoColumns
+	}
+
+	var query strings.Builder
+	var params []any
+	query.WriteString("UPDATE ")
+	query.WriteString(table)
+	query.WriteString(" SET ")
+	for i, column := range columns {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString(column.name)
+		query.WriteString(" = ")
+		if column.null {
+			query.WriteString("NULL")
+			continue
+		}
+		params = append(params, column.value)
+		query.WriteString(s.placeholder(len(params)))
+	}
+
+	if where != "" {
+		rebound, count := s.rebind(where, len(params))
+		if count != len(args) {
+			return "", nil, fmt.Errorf("SQL.Update() called with %d args for %d placeholders", len(args), count)
+		}
+		query.WriteString(" WHERE ")
+		query.WriteString(rebound)
+		params = append(params, args...)
+	} else if len(args) > 0 {
+		return "", nil, fmt.Errorf("SQL.Update() called with %d args for %d placeholders", len(args), 0)
+	}
+	return query.String(), params, nil
+}
+
+/*
+BuildInsert builds an INSERT statement using a zero SQL.
+*/
+func BuildInsert(table string, rows any, returning ...string) (string, []any, error) {
+	return SQL{}.Insert(table, rows, returning...)
+}
+
+/*
+Insert builds an INSERT statement for table from rows, which is a struct or a slice of structs.
+The columns are named by the db tags of the present Nullable fields, so absent fields fall back to the column's DEFAULT.
+Fields holding values are passed as parameters and null fields insert NULL.
+Nested structs and pointers to structs are walked recursively, and fields that aren't Nullable are ignored.
+
+	type NewUser struct {
+		Name  nullable.Nullable[string] `db:"name"`
+		Email nullable.Nullable[string] `db:"email"`
+		Role  nullable.Nullable[string] `db:"role"`
+	}
+
+	users := []NewUser{
+		{Name: nullable.From("Ann"), Role: nullable.From("admin")},
+		{Name: nullable.From("Bob"), Email: nullable.Null[string]()},
+	}
+	query, args, err := nullable.SQL{Dialect: nullable.Postgres}.Insert("users", users, "id")
+	// INSERT INTO users (name, role, email) VALUES ($1, $2, DEFAULT), ($3, DEFAULT, NULL) RETURNING id
+	// [Ann admin Bob]
+
+When rows differ in which fields are present, the missing columns are written as DEFAULT.
+Only the Postgres and MySQL dialects allow this; with other dialects use InsertBatches instead.
+A RETURNING clause is added if returning names any columns, which the MySQL dialect doesn't allow.
+*/
+func (s SQL) Insert(table string, rows any, returning ...string) (string, []any, error) {
+	columns, err := sqlRows(rows, "SQL.Insert()")
+	if err != nil {
+		return "", nil, err
+	}
+	return s.insert(table, columns, returning)
+}
+
+/*
+InsertBatches is like Insert, but builds one statement for each set of present fields, in the order the sets first appear in rows.
+Rows therefore never need DEFAULT, which makes InsertBatches work with every dialect.
+*/
+func (s SQL) InsertBatches(table string, rows any, returning ...string) ([]Statement, error) {
+	columns, err := sqlRows(rows, "SQL.InsertBatches()")
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	groups := map[string][][]sqlColumn{}
+	for _, row := range columns {
+		names := make([]string, len(row))
+		for i, column := range row {
+			names[i] = column.name
+		}
+		key := strings.Join(names, "\x00")
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], row)
+	}
+
+	statements := make([]Statement, 0, len(keys))
+	for _, key := range keys {
+		query, args, err := s.insert(table, groups[key], returning)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, Statement{Query: query, Args: args})
+	}
+	return statements, nil
+}
+
+func (s SQL) insert(table string, rows [][]sqlColumn, returning []string) (string, []any, error) {
+	if len(returning) > 0 && s.Dialect == MySQL {
+		return "", nil, errors.New("SQL.Insert() called with RETURNING columns for the MySQL dialect")
+	}
+
+	var names []string
+	seen := map[string]bool{}
+	for _, row := range rows {
+		for _, column := range row {
+			if !seen[column.name] {
+				seen[column.name] = true
+				names = append(names, column.name)
+			}
+		}
+	}
+	for _, row := range rows {
+		if len(row) != len(names) && s.Dialect != Postgres && s.Dialect != MySQL {
+			return "", nil, errors.New("SQL.Insert() called with rows that differ in present fields. Use InsertBatches or the Postgres or MySQL dialect")
+		}
+	}
+
+	var query strings.Builder
+	var params []any
+	query.WriteString("INSERT INTO ")
+	query.WriteString(table)
+	switch {
+	case len(names) == 0 && s.Dialect == MySQL:
+		query.WriteString(" () VALUES ()")
+		query.WriteString(strings.Repeat(", ()", len(rows)-1))
+	case len(names) == 0 && len(rows) == 1:
+		query.WriteString(" DEFAULT VALUES")
+	case len(names) == 0:
+		return "", nil, ErrNoColumns
+	default:
+		query.WriteString(" (")
+		query.WriteString(strings.Join(names, ", "))
+		query.WriteString(") VALUES ")
+		for i, row := range rows {
+			if i > 0 {
+				query.WriteString(", ")
+			}
+			values := make(map[string]sqlColumn, len(row))
+			for _, column := range row {
+				values[column.name] = column
+			}
+			query.WriteString("(")
+			for j, name := range names {
+				if j > 0 {
+					query.WriteString(", ")
+				}
+				column, ok := values[name]
+				switch {
+				case !ok:
+					query.WriteString("DEFAULT")
+				case column.null:
+					query.WriteString("NULL")
+				default:
+					params = append(params, column.value)
+					query.WriteString(s.placeholder(len(params)))
+				}
+			}
+			query.WriteString(")")
+		}
+	}
+
+	if len(returning) > 0 {
+		query.WriteString(" RETURNING ")
+		query.WriteString(strings.Join(returning, ", "))
+	}
+	return query.String(), params, nil
+}
+
+/*
+sqlColumn is a present Nullable field and the column named by its db tag.
+*/
+type sqlColumn struct {
+	name  string
+	null  bool
+	value any
+}
+
+/*
+sqlColumns returns the present Nullable fields of the struct v in field order.
+caller names the function for error messages.
+*/
+func sqlColumns(v any, caller string) ([]sqlColumn, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s called with a %T. Expected a struct", caller, v)
+	}
+	return sqlStructColumns(rv), nil
+}
+
+/*
+sqlStructColumns returns the present Nullable fields of the struct rv in field order.
+*/
+func sqlStructColumns(rv reflect.Value) []sqlColumn {
+	var columns []sqlColumn
+	var collect func(rv reflect.Value, path string)
+	collect = func(rv reflect.Value, path string) {
+		walkTagged(rv, "db", path, false, func(fv reflect.Value, tag, fieldPath string, n reflector) {
+			if n == nil {
+				collect(fv, fieldPath)
+				return
+			}
+			if !n.IsPresent() {
+				return
+			}
+			column := sqlColumn{name: tag, null: n.IsNull()}
+			if !column.null {
+				column.value = n.reflectValue().Interface()
+			}
+			columns = append(columns, column)
+		}, nil)
+	}
+	collect(addressable(rv), "")
+	return columns
+}
+
+/*
+sqlRows returns the present Nullable fields of each row, where rows is a struct or a slice or array of structs.
+caller names the function for error messages.
+*/
+func sqlRows(rows any, caller string) ([][]sqlColumn, error) {
+	rv := reflect.ValueOf(rows)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Struct:
+		return [][]sqlColumn{sqlStructColumns(rv)}, nil
+	case reflect.Slice, reflect.Array:
+		if rv.Len() == 0 {
+			return nil, fmt.Errorf("%s called without any rows", caller)
+		}
+		columns := make([][]sqlColumn, rv.Len())
+		for i := range columns {
+			ev := rv.Index(i)
+			for ev.Kind() == reflect.Pointer && !ev.IsNil() {
+				ev = ev.Elem()
+			}
+			if ev.Kind() != reflect.Struct {
+				return nil, fmt.Errorf("%s called with row %d of type %v. Expected a struct", caller, i, rv.Index(i).Type())
+			}
+			columns[i] = sqlStructColumns(ev)
+		}
+		return columns, nil
+	}
+	return nil, fmt.Errorf("%s called with a %T. Expected a struct or a slice of structs", caller, rows)
+}
+
+/*
+placeholder returns the placeholder for the nth parameter, counting from 1.
+*/
+func (s SQL) placeholder(n int) string {
+	if s.Placeholders == Dollar || s.Dialect == Postgres {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+/*
+rebind rewrites the ? placeholders of query to the configured style, numbering them after offset, and returns the number of placeholders.
+Question marks within single quoted strings, double quoted identifiers and backquoted identifiers are left alone.
+*/
+func (s SQL) rebind(query string, offset int) (string, int) {
+	var out strings.Builder
+	count := 0
+	var quote rune
+	for _, r := range query {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '?':
+			count++
+			out.WriteString(s.placeholder(offset + count))
+			continue
+		}
+		out.WriteRune(r)
+	}
+	return out.String(), count
+}
@@ -0,0 +1,347 @@
+package nullable
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+/*
+recordDriver is an in-memory database/sql driver that records the statements executed through it.
+*/
+type recordDriver struct {
+	mu    sync.Mutex
+	execs []recordedExec
+}
+
+type recordedExec struct {
+	query string
+	args  []driver.Value
+}
+
+type recordConn struct{ d *recordDriver }
+
+type recordStmt struct {
+	d     *recordDriver
+	query string
+}
+
+var testDriver = &recordDriver{}
+
+func init() {
+	sql.Register("nullable-record", testDriver)
+}
+
+func (d *recordDriver) Open(name string) (driver.Conn, error) { return recordConn{d}, nil }
+
+func (d *recordDriver) last() recordedExec {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.execs[len(d.execs)-1]
+}
+
+func (c recordConn) Prepare(query string) (driver.Stmt, error) {
+	return recordStmt{c.d, query}, nil
+}
+func (c recordConn) Close() error { return nil }
+func (c recordConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+func (s recordStmt) Close() error  { return nil }
+func (s recordStmt) NumInput() int { return -1 }
+func (s recordStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.execs = append(s.d.execs, recordedExec{s.query, args})
+	return driver.RowsAffected(1), nil
+}
+func (s recordStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("queries are not supported")
+}
+
+type sqlUserPatch struct {
+	ID      int                 `db:"id"`
+	Name    Nullable[string]    `db:"name"`
+	Email   Nullable[string]    `db:"email"`
+	Age     Nullable[int]       `db:"age"`
+	Seen    Nullable[time.Time] `db:"seen_at"`
+	Skipped Nullable[bool]      `db:"-"`
+	Audit   *sqlAudit
+}
+
+type sqlAudit struct {
+	By Nullable[string] `db:"updated_by"`
+}
+
+func TestUpdate(t *testing.T) {
+	seen := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	patch := sqlUserPatch{
+		ID:      1,
+		Name:    From("Ann"),
+		Email:   Null[string](),
+		Seen:    From(seen),
+		Skipped: From(true),
+		Audit:   &sqlAudit{By: From("admin")},
+	}
+	{
+		query, args, err := SQL{}.Update("users", &patch, "id = ?", 7)
+		if err != nil {
+			t.Fatalf("Update() err = %v. Expected %v.", err, nil)
+		}
+		expected := "UPDATE users SET name = ?, email = NULL, seen_at = ?, updated_by = ? WHERE id = ?"
+		if query != expected {
+			t.Errorf("Update() = %s. Expected %s.", query, expected)
+		}
+		if expected := []any{"Ann", seen, "admin", 7}; !reflect.DeepEqual(args, expected) {
+			t.Errorf("Update() args = %v. Expected %v.", args, expected)
+		}
+	}
+	{
+		query, args, err := SQL{Placeholders: Dollar}.Update("users", &patch, "id = ? AND note <> '?' AND \"a?\" = ?", 7, "x")
+		if err != nil {
+			t.Fatalf("Update() err = %v. Expected %v.", err, nil)
+		}
+		expected := "UPDATE users SET name = $1, email = NULL, seen_at = $2, updated_by = $3 WHERE id = $4 AND note <> '?' AND \"a?\" = $5"
+		if query != expected {
+			t.Errorf("Update() = %s. Expected %s.", query, expected)
+		}
+		if expected := []any{"Ann", seen, "admin", 7, "x"}; !reflect.DeepEqual(args, expected) {
+			t.Errorf("Update() args = %v. Expected %v.", args, expected)
+		}
+	}
+	{
+		query, args, err := SQL{Placeholders: Dollar}.Update("users", &patch, "")
+		if err != nil {
+			t.Fatalf("Update() err = %v. Expected %v.", err, nil)
+		}
+		expected := "UPDATE users SET name = $1, email = NULL, seen_at = $2, updated_by = $3"
+		if query != expected {
+			t.Errorf("Update() = %s. Expected %s.", query, expected)
+		}
+		if expected := []any{"Ann", seen, "admin"}; !reflect.DeepEqual(args, expected) {
+			t.Errorf("Update() args = %v. Expected %v.", args, expected)
+		}
+	}
+
+	if _, _, err := BuildUpdate("users", sqlUserPatch{ID: 1}, "id = ?", 1); err != ErrNoColumns {
+		t.Errorf("BuildUpdate(absent) err = %v. Expected %v.", err, ErrNoColumns)
+	}
+	if _, _, err := BuildUpdate("users", patch, "id = ?"); err == nil {
+		t.Errorf("BuildUpdate() with too few args err = nil. Expected an error.")
+	}
+	if _, _, err := BuildUpdate("users", 1, ""); err == nil {
+		t.Errorf("BuildUpdate(1) err = nil. Expected an error.")
+	}
+}
+
+func TestUpdateExec(t *testing.T) {
+	db, err := sql.Open("nullable-record", "")
+	if err != nil {
+		t.Fatalf("sql.Open() err = %v. Expected nil.", err)
+	}
+	defer db.Close()
+
+	patch := sqlUserPatch{Name: From("Ann"), Age: From(30), Email: Null[string]()}
+	query, args, err := SQL{Placeholders: Dollar}.Update("users", patch, "id = ?", 7)
+	if err != nil {
+		t.Fatalf("Update() err = %v. Expected nil.", err)
+	}
+	if _, err := db.Exec(query, args...); err != nil {
+		t.Fatalf("db.Exec() err = %v. Expected nil.", err)
+	}
+
+	got := testDriver.last()
+	if expected := "UPDATE users SET name = $1, email = NULL, age = $2 WHERE id = $3"; got.query != expected {
+		t.Errorf("db.Exec() query = %s. Expected %s.", got.query, expected)
+	}
+	if expected := []driver.Value{"Ann", int64(30), int64(7)}; !reflect.DeepEqual(got.args, expected) {
+		t.Errorf("db.Exec() args = %#v. Expected %#v.", got.args, expected)
+	}
+}
+
+type sqlNewUser struct {
+	Name  Nullable[string] `db:"name"`
+	Email Nullable[string] `db:"email"`
+	Role  Nullable[string] `db:"role"`
+}
+
+func TestInsert(t *testing.T) {
+	users := []sqlNewUser{
+		{Name: From("Ann"), Role: From("admin")},
+		{Name: From("Bob"), Email: Null[string]()},
+	}
+	{
+		query, args, err := SQL{}.Insert("users", users[0])
+		if err != nil {
+			t.Fatalf("Insert() err = %v. Expected %v.", err, nil)
+		}
+		expected := "INSERT INTO users (name, role) VALUES (?, ?)"
+		if query != expected {
+			t.Errorf("Insert() = %s. Expected %s.", query, expected)
+		}
+		if expected := []any{"Ann", "admin"}; !reflect.DeepEqual(args, expected) {
+			t.Errorf("Insert() args = %v. Expected %v.", args, expected)
+		}
+	}
+	{
+		query, args, err := SQL{Dialect: SQLite}.Insert("users", &users[1], "id")
+		if err != nil {
+			t.Fatalf("Insert() err = %v. Expected %v.", err, nil)
+		}
+		expected := "INSERT INTO users (name, email) VALUES (?, NULL) RETURNING id"
+		if query != expected {
+			t.Errorf("Insert() = %s. Expected %s.", query, expected)
+		}
+		if expected := []any{"Bob"}; !reflect.DeepEqual(args, expected) {
+			t.Errorf("Insert() args = %v. Expected %v.", args, expected)
+		}
+	}
+	{
+		query, args, err := SQL{Dialect: Postgres}.Insert("users", users, "id", "created_at")
+		if err != nil {
+			t.Fatalf("Insert() err = %v. Expected %v.", err, nil)
+		}
+		expected := "INSERT INTO users (name, role, email) VALUES ($1, $2, DEFAULT), ($3, DEFAULT, NULL) RETURNING id, created_at"
+		if query != expected {
+			t.Errorf("Insert() = %s. Expected %s.", query, expected)
+		}
+		if expected := []any{"Ann", "admin", "Bob"}; !reflect.DeepEqual(args, expected) {
+			t.Errorf("Insert() args = %v. Expected %v.", args, expected)
+		}
+	}
+	{
+		query, args, err := SQL{Dialect: MySQL}.Insert("users", []*sqlNewUser{&users[0], &users[1]})
+		if err != nil {
+			t.Fatalf("Insert() err = %v. Expected %v.", err, nil)
+		}
+		expected := "INSERT INTO users (name, role, email) VALUES (?, ?, DEFAULT), (?, DEFAULT, NULL)"
+		if query != expected {
+			t.Errorf("Insert() = %s. Expected %s.", query, expected)
+		}
+		if expected := []any{"Ann", "admin", "Bob"}; !reflect.DeepEqual(args, expected) {
+			t.Errorf("Insert() args = %v. Expected %v.", args, expected)
+		}
+	}
+	{
+		query, args, err := SQL{Placeholders: Dollar}.Insert("users", [2]sqlNewUser{users[0], users[0]})
+		if err != nil {
+			t.Fatalf("Insert() err = %v. Expected %v.", err, nil)
+		}
+		expected := "INSERT INTO users (name, role) VALUES ($1, $2), ($3, $4)"
+		if query != expected {
+			t.Errorf("Insert() = %s. Expected %s.", query, expected)
+		}
+		if expected := []any{"Ann", "admin", "Ann", "admin"}; !reflect.DeepEqual(args, expected) {
+			t.Errorf("Insert() args = %v. Expected %v.", args, expected)
+		}
+	}
+	{
+		query, args, err := SQL{Dialect: Postgres}.Insert("users", sqlNewUser{}, "id")
+		if err != nil {
+			t.Fatalf("Insert() err = %v. Expected %v.", err, nil)
+		}
+		expected := "INSERT INTO users DEFAULT VALUES RETURNING id"
+		if query != expected {
+			t.Errorf("Insert() = %s. Expected %s.", query, expected)
+		}
+		if expected := []any(nil); !reflect.DeepEqual(args, expected) {
+			t.Errorf("Insert() args = %v. Expected %v.", args, expected)
+		}
+	}
+	{
+		query, args, err := SQL{Dialect: MySQL}.Insert("users", []sqlNewUser{{}, {}})
+		if err != nil {
+			t.Fatalf("Insert() err = %v. Expected %v.", err, nil)
+		}
+		expected := "INSERT INTO users () VALUES (), ()"
+		if query != expected {
+			t.Errorf("Insert() = %s. Expected %s.", query, expected)
+		}
+		if expected := []any(nil); !reflect.DeepEqual(args, expected) {
+			t.Errorf("Insert() args = %v. Expected %v.", args, expected)
+		}
+	}
+	{
+		if _, _, err := (SQL{}).Insert("users", users); err == nil {
+			t.Errorf("Insert() err = %v. Expected error.", err)
+		}
+	}
+	{
+		if _, _, err := (SQL{Dialect: SQLite}).Insert("users", users); err == nil {
+			t.Errorf("Insert() err = %v. Expected error.", err)
+		}
+	}
+	{
+		if _, _, err := (SQL{Dialect: MySQL}).Insert("users", users[0], "id"); err == nil {
+			t.Errorf("Insert() err = %v. Expected error.", err)
+		}
+	}
+	{
+		if _, _, err := (SQL{Dialect: Postgres}).Insert("users", []sqlNewUser{{}, {}}); err == nil {
+			t.Errorf("Insert() err = %v. Expected error.", err)
+		}
+	}
+	{
+		if _, _, err := (SQL{}).Insert("users", []sqlNewUser{}); err == nil {
+			t.Errorf("Insert() err = %v. Expected error.", err)
+		}
+	}
+	{
+		if _, _, err := (SQL{}).Insert("users", []int{1}); err == nil {
+			t.Errorf("Insert() err = %v. Expected error.", err)
+		}
+	}
+	{
+		if _, _, err := (SQL{}).Insert("users", 1); err == nil {
+			t.Errorf("Insert() err = %v. Expected error.", err)
+		}
+	}
+}
+
+func TestInsertBatches(t *testing.T) {
+	users := []sqlNewUser{
+		{Name: From("Ann"), Role: From("admin")},
+		{Name: From("Bob"), Email: Null[string]()},
+		{Name: From("Cat"), Role: From("user")},
+	}
+	statements, err := SQL{Dialect: SQLite}.InsertBatches("users", users, "id")
+	if err != nil {
+		t.Fatalf("InsertBatches() err = %v. Expected nil.", err)
+	}
+	expected := []Statement{
+		{"INSERT INTO users (name, role) VALUES (?, ?), (?, ?) RETURNING id", []any{"Ann", "admin", "Cat", "user"}},
+		{"INSERT INTO users (name, email) VALUES (?, NULL) RETURNING id", []any{"Bob"}},
+	}
+	if !reflect.DeepEqual(statements, expected) {
+		t.Errorf("InsertBatches() = %v. Expected %v.", statements, expected)
+	}
+}
+
+func TestInsertExec(t *testing.T) {
+	db, err := sql.Open("nullable-record", "")
+	if err != nil {
+		t.Fatalf("sql.Open() err = %v. Expected nil.", err)
+	}
+	defer db.Close()
+
+	query, args, err := BuildInsert("users", sqlNewUser{Name: From("Ann"), Email: Null[string]()})
+	if err != nil {
+		t.Fatalf("BuildInsert() err = %v. Expected nil.", err)
+	}
+	if _, err := db.Exec(query, args...); err != nil {
+		t.Fatalf("db.Exec() err = %v. Expected nil.", err)
+	}
+
+	got := testDriver.last()
+	if expected := "INSERT INTO users (name, email) VALUES (?, NULL)"; got.query != expected {
+		t.Errorf("db.Exec() query = %s. Expected %s.", got.query, expected)
+	}
+	if expected := []driver.Value{"Ann"}; !reflect.DeepEqual(got.args, expected) {
+		t.Errorf("db.Exec() args = %#v. Expected %#v.", got.args, expected)
+	}
+}
@@ -0,0 +1,312 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+StrictReason describes why a key was rejected by Strict.
+*/
+type StrictReason int
+
+const (
+	// ReasonAbsent means that a Nullable tagged as required was absent.
+	ReasonAbsent StrictReason = iota + 1
+	// ReasonNull means that a Nullable tagged as nonnull was null.
+	ReasonNull
+	// ReasonUnknown means that a key didn't match any field while unknown keys were disallowed.
+	ReasonUnknown
+)
+
+func (r StrictReason) String() string {
+	switch r {
+	case ReasonAbsent:
+		return "absent"
+	case ReasonNull:
+		return "null"
+	case ReasonUnknown:
+		return "unknown"
+	}
+	return "StrictReason(" + strconv.Itoa(int(r)) + ")"
+}
+
+/*
+StrictError describes a single key that was rejected by Strict.
+Path is a JSON path such as $.items[3].price.
+*/
+type StrictError struct {
+	Path   string
+	Reason StrictReason
+}
+
+func (e *StrictError) Error() string {
+	switch e.Reason {
+	case ReasonAbsent:
+		return e.Path + " is required but was absent"
+	case ReasonNull:
+		return e.Path + " must not be null"
+	case ReasonUnknown:
+		return e.Path + " is not a known field"
+	}
+	return e.Path + " was rejected as " + e.Reason.String()
+}
+
+/*
+StrictErrors holds every StrictError found in a single document.
+*/
+type StrictErrors []*StrictError
+
+func (e StrictErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+/*
+Strict holds the options used to strictly decode JSON.
+The zero value is ready to use.
+*/
+type Strict struct {
+	// DisallowUnknownFields rejects object keys that don't match any field of the destination struct.
+	DisallowUnknownFields bool
+}
+
+/*
+UnmarshalStrict decodes data into v using a zero Strict.
+*/
+func UnmarshalStrict(data []byte, v any) error {
+	return Strict{}.Unmarshal(data, v)
+}
+
+/*
+Unmarshal decodes data into v with json.Unmarshal and then enforces the nullable tags of its fields.
+A field tagged nullable:"required" must be present, and a field tagged nullable:"nonnull" must not be null if it is present.
+The two can be combined as nullable:"required,nonnull".
+
+	type Item struct {
+		Price nullable.Nullable[float64] `json:"price" nullable:"required,nonnull"`
+	}
+	type Order struct {
+		Items []Item `json:"items"`
+	}
+
+	var order Order
+	err := nullable.UnmarshalStrict([]byte(`{"items": [{"price": null}]}`), &order)
+	// err is StrictErrors{{Path: "$.items[0].price", Reason: nullable.ReasonNull}}
+
+Tags are enforced on nested objects, arrays and maps wherever the document holds them.
+Syntax and type errors from encoding/json are returned unchanged, and every rejected key is reported together as StrictErrors.
+*/
+func (s Strict) Unmarshal(data []byte, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	var raw any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+
+	var errs StrictErrors
+	s.check(raw, reflect.TypeOf(v), "$", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+func (s Strict) check(raw any, typ reflect.Type, path string, errs *StrictErrors) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if n, ok := reflect.New(typ).Interface().(reflector); ok {
+		typ = n.elemType()
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+	}
+	if raw == nil || reflect.PtrTo(typ).Implements(jsonUnmarshalerType) {
+		return
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		obj, ok := raw.(map[string]any)
+		if !ok {
+			return
+		}
+		fields := jsonFields(typ)
+		for _, field := range fields {
+			key, val, ok := lookupKey(obj, field.name)
+			fieldPath := path + jsonPathKey(field.name)
+			if ok {
+				fieldPath = path + jsonPathKey(key)
+			}
+			required, nonnull := strictOptions(field.tag)
+			switch {
+			case !ok && required:
+				*errs = append(*errs, &StrictError{Path: fieldPath, Reason: ReasonAbsent})
+			case ok && val == nil && nonnull:
+				*errs = append(*errs, &StrictError{Path: fieldPath, Reason: ReasonNull})
+			case ok:
+				s.check(val, field.typ, fieldPath, errs)
+			}
+		}
+		if s.DisallowUnknownFields {
+			for _, key := range sortedKeys(obj) {
+				if !hasField(fields, key) {
+					*errs = append(*errs, &StrictError{Path: path + jsonPathKey(key), Reason: ReasonUnknown})
+				}
+			}
+		}
+	case reflect.Map:
+		obj, ok := raw.(map[string]any)
+		if !ok {
+			return
+		}
+		for _, key := range sortedKeys(obj) {
+			s.check(obj[key], typ.Elem(), path+jsonPathKey(key), errs)
+		}
+	case reflect.Slice, reflect.Array:
+		arr, ok := raw.([]any)
+		if !ok {
+			return
+		}
+		for i, val := range arr {
+			s.check(val, typ.Elem(), path+"["+strconv.Itoa(i)+"]", errs)
+		}
+	}
+}
+
+/*
+strictOptions parses a nullable tag.
+*/
+func strictOptions(tag string) (required bool, nonnull bool) {
+	for _, opt := range strings.Split(tag, ",") {
+		switch strings.TrimSpace(opt) {
+		case "required":
+			required = true
+		case "nonnull":
+			nonnull = true
+		}
+	}
+	return required, nonnull
+}
+
+/*
+jsonField is a struct field as seen by encoding/json.
+*/
+type jsonField struct {
+	name  string
+	typ   reflect.Type
+	tag   string
+	index []int
+}
+
+/*
+jsonFields returns the fields of the struct type typ under their json names.
+Fields of embedded structs without a json name are promoted after the fields of typ itself, so that shallower fields take precedence in lookups.
+*/
+func jsonFields(typ reflect.Type) []jsonField {
+	var fields []jsonField
+	var embedded []int
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		ft := field.Type
+		if field.Anonymous && name == "" {
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, i)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, jsonField{name: name, typ: field.Type, tag: field.Tag.Get("nullable"), index: []int{i}})
+	}
+	for _, i := range embedded {
+		ft := typ.Field(i).Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		for _, field := range jsonFields(ft) {
+			field.index = append([]int{i}, field.index...)
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+/*
+lookupKey finds the key matching name in obj, preferring an exact match to a case-insensitive one like encoding/json.
+*/
+func lookupKey(obj map[string]any, name string) (string, any, bool) {
+	if val, ok := obj[name]; ok {
+		return name, val, true
+	}
+	for _, key := range sortedKeys(obj) {
+		if strings.EqualFold(key, name) {
+			return key, obj[key], true
+		}
+	}
+	return "", nil, false
+}
+
+func hasField(fields []jsonField, key string) bool {
+	for _, field := range fields {
+		if strings.EqualFold(field.name, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(obj map[string]any) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+/*
+jsonPathKey formats key as a JSON path member, quoting it if it isn't a plain identifier.
+*/
+func jsonPathKey(key string) string {
+	for i, r := range key {
+		isLetter := r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+		isDigit := r >= '0' && r <= '9'
+		if !isLetter && !(isDigit && i > 0) {
+			return fmt.Sprintf("[%q]", key)
+		}
+	}
+	if key == "" {
+		return `[""]`
+	}
+	return "." + key
+}
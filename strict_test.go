@@ -0,0 +1,101 @@
+package nullable
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestUnmarshalStrict(t *testing.T) {
+	type Item struct {
+		Price Nullable[float64] `json:"price" nullable:"required,nonnull"`
+		Note  Nullable[string]  `json:"note" nullable:"nonnull"`
+	}
+	type Order struct {
+		ID    Nullable[int]            `json:"id" nullable:"required"`
+		Items []Item                   `json:"items"`
+		Extra Nullable[[]Item]         `json:"extra"`
+		ByKey map[string]Item          `json:"by_key"`
+		Meta  Nullable[map[string]int] `json:"meta"`
+	}
+	{
+		var got Order
+		err := UnmarshalStrict([]byte(`{"id": null, "items": [{"price": 1.5}, {"price": 2, "note": "x"}]}`), &got)
+		if err != nil {
+			t.Errorf("UnmarshalStrict(...) = %v. Expected %v.", err, nil)
+		}
+		if len(got.Items) != 2 || got.Items[1].Price.ValueOr(0) != 2 {
+			t.Errorf("got.Items = %+v. Expected two items.", got.Items)
+		}
+	}
+	{
+		var got Order
+		err := UnmarshalStrict([]byte(`{
+			"items": [{"price": 1}, {"note": null}],
+			"extra": [{"price": null}],
+			"by_key": {"a b": {}}
+		}`), &got)
+		var errs StrictErrors
+		if !errors.As(err, &errs) {
+			t.Fatalf("UnmarshalStrict(...) = %v. Expected StrictErrors.", err)
+		}
+		want := StrictErrors{
+			{Path: "$.id", Reason: ReasonAbsent},
+			{Path: "$.items[1].price", Reason: ReasonAbsent},
+			{Path: "$.items[1].note", Reason: ReasonNull},
+			{Path: "$.extra[0].price", Reason: ReasonNull},
+			{Path: `$.by_key["a b"].price`, Reason: ReasonAbsent},
+		}
+		if !reflect.DeepEqual(errs, want) {
+			t.Errorf("errs = %v. Expected %v.", errs, want)
+		}
+	}
+	{
+		type Base struct {
+			Kind Nullable[string] `json:"kind" nullable:"required"`
+		}
+		type S struct {
+			Base
+			Name Nullable[string] `json:"name"`
+		}
+		var got S
+		err := Strict{DisallowUnknownFields: true}.Unmarshal([]byte(`{"KIND": "a", "name": "b", "other": 1, "meta": {"x": 1}}`), &got)
+		var errs StrictErrors
+		if !errors.As(err, &errs) {
+			t.Fatalf("Unmarshal(...) = %v. Expected StrictErrors.", err)
+		}
+		want := StrictErrors{
+			{Path: "$.meta", Reason: ReasonUnknown},
+			{Path: "$.other", Reason: ReasonUnknown},
+		}
+		if !reflect.DeepEqual(errs, want) {
+			t.Errorf("errs = %v. Expected %v.", errs, want)
+		}
+		if got.Kind.ValueOr("") != "a" {
+			t.Errorf("got.Kind = %+v. Expected %v.", got.Kind, "a")
+		}
+	}
+	{
+		var got Order
+		err := UnmarshalStrict([]byte(`{"id": "one"}`), &got)
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) {
+			t.Errorf("UnmarshalStrict(...) = %v. Expected *json.UnmarshalTypeError.", err)
+		}
+	}
+}
+
+func TestStrictError(t *testing.T) {
+	{
+		err := &StrictError{Path: "$.a", Reason: ReasonNull}
+		if got := err.Error(); got != "$.a must not be null" {
+			t.Errorf("err.Error() = %q. Expected %q.", got, "$.a must not be null")
+		}
+	}
+	{
+		if got := StrictReason(10).String(); got != "StrictReason(10)" {
+			t.Errorf("StrictReason(10).String() = %q. Expected %q.", got, "StrictReason(10)")
+		}
+	}
+}
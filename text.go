@@ -0,0 +1,37 @@
+package nullable
+
+import (
+	"reflect"
+
+	"github.com/missingsemi/nullable/internal/textconv"
+)
+
+/*
+unmarshalText parses text into the Nullable and marks it as present.
+The text is parsed by textconv.Parse.
+Text is never treated as null, so callers that need a null token should check for it and call Clear instead.
+
+Nullable deliberately doesn't implement encoding.TextUnmarshaler, which encoders such as yaml.v3 and msgpack would otherwise prefer over their own rules.
+*/
+func (n *Nullable[T]) unmarshalText(text string) error {
+	n.present = true
+	n.defaulted = false
+	var tmp T
+	if err := textconv.Parse(text, reflect.ValueOf(&tmp).Elem()); err != nil {
+		n.ptr = nil
+		return err
+	}
+	n.ptr = &tmp
+	return nil
+}
+
+/*
+marshalText is the inverse of unmarshalText.
+A null Nullable marshals to empty text, so callers that need a null token should check for it first.
+*/
+func (n Nullable[T]) marshalText() (string, error) {
+	if n.ptr == nil {
+		return "", nil
+	}
+	return textconv.Format(reflect.ValueOf(n.ptr).Elem())
+}
@@ -0,0 +1,124 @@
+package nullable
+
+import (
+	"encoding"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestUnmarshalText(t *testing.T) {
+	{
+		var got Nullable[int]
+		err := got.unmarshalText("10")
+		if err != nil {
+			t.Errorf("got.unmarshalText(10) = %v. Expected %v.", err, nil)
+		} else if got.ptr == nil || *got.ptr != 10 {
+			t.Errorf("got = %+v. Expected %v.", got, 10)
+		}
+		if got.present == false {
+			t.Error("got.IsPresent() = false. Expected true.")
+		}
+	}
+	{
+		var got Nullable[time.Duration]
+		err := got.unmarshalText("1h30m")
+		if err != nil {
+			t.Errorf("got.unmarshalText(1h30m) = %v. Expected %v.", err, nil)
+		} else if got.ptr == nil || *got.ptr != 90*time.Minute {
+			t.Errorf("got = %+v. Expected %v.", got, 90*time.Minute)
+		}
+	}
+	{
+		var got Nullable[time.Time]
+		err := got.unmarshalText("2022-06-01T12:00:00Z")
+		want := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+		if err != nil {
+			t.Errorf("got.unmarshalText(2022-06-01T12:00:00Z) = %v. Expected %v.", err, nil)
+		} else if got.ptr == nil || !got.ptr.Equal(want) {
+			t.Errorf("got = %+v. Expected %v.", got, want)
+		}
+	}
+	{
+		var got Nullable[string]
+		err := got.unmarshalText("null")
+		if err != nil {
+			t.Errorf("got.unmarshalText(null) = %v. Expected %v.", err, nil)
+		} else if got.ptr == nil || *got.ptr != "null" {
+			t.Errorf("got = %+v. Expected %v.", got, "null")
+		}
+	}
+	{
+		var got Nullable[map[string]int]
+		err := got.unmarshalText(`{"a": 1}`)
+		if err != nil {
+			t.Errorf("got.unmarshalText({\"a\": 1}) = %v. Expected %v.", err, nil)
+		} else if got.ptr == nil || (*got.ptr)["a"] != 1 {
+			t.Errorf("got = %+v. Expected %v.", got, map[string]int{"a": 1})
+		}
+	}
+	{
+		var got Nullable[uint8]
+		err := got.unmarshalText("256")
+		if err == nil {
+			t.Errorf("got.unmarshalText(256) = %v. Expected error.", err)
+		}
+		if got.ptr != nil {
+			t.Error("got.IsNull() = false. Expected true.")
+		}
+		if got.present == false {
+			t.Error("got.IsPresent() = false. Expected true.")
+		}
+	}
+}
+
+func TestMarshalText(t *testing.T) {
+	{
+		tmp := 10
+		got, err := Nullable[int]{ptr: &tmp, present: true}.marshalText()
+		if err != nil || string(got) != "10" {
+			t.Errorf("marshalText() = %q, %v. Expected %q, %v.", got, err, "10", nil)
+		}
+	}
+	{
+		tmp := 1.5
+		got, err := Nullable[float32]{ptr: new(float32), present: true}.marshalText()
+		if err != nil || string(got) != "0" {
+			t.Errorf("marshalText() = %q, %v. Expected %q, %v.", got, err, "0", nil)
+		}
+		got, err = Nullable[float64]{ptr: &tmp, present: true}.marshalText()
+		if err != nil || string(got) != "1.5" {
+			t.Errorf("marshalText() = %q, %v. Expected %q, %v.", got, err, "1.5", nil)
+		}
+	}
+	{
+		tmp := net.IPv4(10, 0, 0, 1)
+		got, err := Nullable[net.IP]{ptr: &tmp, present: true}.marshalText()
+		if err != nil || string(got) != "10.0.0.1" {
+			t.Errorf("marshalText() = %q, %v. Expected %q, %v.", got, err, "10.0.0.1", nil)
+		}
+	}
+	{
+		tmp := time.Second
+		got, err := Nullable[time.Duration]{ptr: &tmp, present: true}.marshalText()
+		if err != nil || string(got) != "1s" {
+			t.Errorf("marshalText() = %q, %v. Expected %q, %v.", got, err, "1s", nil)
+		}
+	}
+	{
+		got, err := Nullable[int]{ptr: nil, present: true}.marshalText()
+		if err != nil || string(got) != "" {
+			t.Errorf("marshalText() = %q, %v. Expected %q, %v.", got, err, "", nil)
+		}
+	}
+}
+
+func TestNotTextMarshaler(t *testing.T) {
+	// Encoders such as yaml.v3 and msgpack prefer text methods over their own rules.
+	if _, ok := any(Nullable[int]{}).(encoding.TextMarshaler); ok {
+		t.Errorf("Nullable implements encoding.TextMarshaler. Expected it not to.")
+	}
+	if _, ok := any(&Nullable[int]{}).(encoding.TextUnmarshaler); ok {
+		t.Errorf("*Nullable implements encoding.TextUnmarshaler. Expected it not to.")
+	}
+}
@@ -14,7 +14,7 @@ func TestValidate(t *testing.T) {
 			S Nullable[int] `validate:"required,min=5"`
 		}
 		tmp := 10
-		got := S{Nullable[int]{&tmp, true}}
+		got := S{Nullable[int]{ptr: &tmp, present: true}}
 		if err := validate.Struct(got); err != nil {
 			t.Errorf("validate.Struct(got) = %v. Expected %v.", err, nil)
 		}
@@ -24,7 +24,7 @@ func TestValidate(t *testing.T) {
 			S Nullable[string] `validate:"required,min=5"`
 		}
 		tmp := "hello"
-		got := S{Nullable[string]{&tmp, true}}
+		got := S{Nullable[string]{ptr: &tmp, present: true}}
 		if err := validate.Struct(got); err != nil {
 			t.Errorf("validate.Struct(got) = %v. Expected %v.", err, nil)
 		}
@@ -34,7 +34,7 @@ func TestValidate(t *testing.T) {
 			S Nullable[int] `validate:"required,min=5"`
 		}
 		tmp := 1
-		got := S{Nullable[int]{&tmp, true}}
+		got := S{Nullable[int]{ptr: &tmp, present: true}}
 		if err := validate.Struct(got); err == nil {
 			t.Errorf("validate.Struct(got) = %v. Expected error.", err)
 		}
@@ -44,7 +44,7 @@ func TestValidate(t *testing.T) {
 			S Nullable[string] `validate:"required,min=5"`
 		}
 		tmp := "hi"
-		got := S{Nullable[string]{&tmp, true}}
+		got := S{Nullable[string]{ptr: &tmp, present: true}}
 		if err := validate.Struct(got); err == nil {
 			t.Errorf("validate.Struct(got) = %v. Expected error.", err)
 		}
@@ -54,7 +54,7 @@ func TestValidate(t *testing.T) {
 			S Nullable[int] `validate:"required,min=5"`
 		}
 		tmp := 10
-		got := S{Nullable[int]{&tmp, false}}
+		got := S{Nullable[int]{ptr: &tmp, present: false}}
 		if err := validate.Struct(got); err == nil {
 			t.Errorf("validate.Struct(got) = %v. Expected error.", err)
 		}
@@ -64,7 +64,7 @@ func TestValidate(t *testing.T) {
 			S Nullable[string] `validate:"required,min=5"`
 		}
 		tmp := "hello"
-		got := S{Nullable[string]{&tmp, false}}
+		got := S{Nullable[string]{ptr: &tmp, present: false}}
 		if err := validate.Struct(got); err == nil {
 			t.Errorf("validate.Struct(got) = %v. Expected error.", err)
 		}
@@ -73,7 +73,7 @@ func TestValidate(t *testing.T) {
 		type S struct {
 			S Nullable[int] `validate:"required,min=5"`
 		}
-		got := S{Nullable[int]{nil, true}}
+		got := S{Nullable[int]{ptr: nil, present: true}}
 		if err := validate.Struct(got); err == nil {
 			t.Errorf("validate.Struct(got) = %v. Expected error.", err)
 		}
@@ -82,7 +82,7 @@ func TestValidate(t *testing.T) {
 		type S struct {
 			S Nullable[string] `validate:"required,min=5"`
 		}
-		got := S{Nullable[string]{nil, true}}
+		got := S{Nullable[string]{ptr: nil, present: true}}
 		if err := validate.Struct(got); err == nil {
 			t.Errorf("validate.Struct(got) = %v. Expected error.", err)
 		}
@@ -91,7 +91,7 @@ func TestValidate(t *testing.T) {
 		type S struct {
 			S Nullable[int] `validate:"required,min=5"`
 		}
-		got := S{Nullable[int]{nil, false}}
+		got := S{Nullable[int]{ptr: nil, present: false}}
 		if err := validate.Struct(got); err == nil {
 			t.Errorf("validate.Struct(got) = %v. Expected error.", err)
 		}
@@ -100,7 +100,7 @@ func TestValidate(t *testing.T) {
 		type S struct {
 			S Nullable[string] `validate:"required,min=5"`
 		}
-		got := S{Nullable[string]{nil, false}}
+		got := S{Nullable[string]{ptr: nil, present: false}}
 		if err := validate.Struct(got); err == nil {
 			t.Errorf("validate.Struct(got) = %v. Expected error.", err)
 		}